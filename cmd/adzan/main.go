@@ -7,19 +7,23 @@ import (
 	"os"
 
 	"github.com/joho/godotenv"
+	"github.com/taufiq30s/adzan/internal/calc"
 	"github.com/taufiq30s/adzan/internal/routes"
 )
 
 func main() {
 	defer catch()
 
-	router := routes.NewRoute()
-
 	port, err := getPort()
 	if err != nil {
 		panic(err)
 	}
 
+	if err := loadMethods(); err != nil {
+		panic(err)
+	}
+	router := routes.NewRoute()
+
 	addr := fmt.Sprintf(":%s", port)
 	fmt.Printf("Server listening on http://localhost%s\n", addr)
 	err = http.ListenAndServe(addr, router)
@@ -36,6 +40,20 @@ func getPort() (string, error) {
 	return os.Getenv("PORT"), nil
 }
 
+// Register custom calculation methods from the JSON file in METHODS_FILE
+func loadMethods() error {
+	path := os.Getenv("METHODS_FILE")
+	if path == "" {
+		return nil
+	}
+	methods, err := calc.LoadMethodsFile(path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Loaded %d calculation methods from %s\n", len(methods), path)
+	return nil
+}
+
 func catch() {
 	if r := recover(); r != nil {
 		log.Printf("panic: %v", r)
@@ -4,10 +4,11 @@ go 1.22.0
 
 require github.com/joho/godotenv v1.5.1 // direct
 
+require github.com/ringsaturn/tzf v0.15.0
+
 require (
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
 	github.com/paulmach/orb v0.11.1 // indirect
-	github.com/ringsaturn/tzf v0.15.0 // indirect
 	github.com/ringsaturn/tzf-rel-lite v0.0.2024-a // indirect
 	github.com/tidwall/geoindex v1.7.0 // indirect
 	github.com/tidwall/geojson v1.4.5 // indirect
@@ -1,5 +1,18 @@
 package api
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/calc"
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
 type adzanData struct {
 	Date     string `json:"date"`
 	HijrDate string `json:"hijrDate"`
@@ -10,179 +23,543 @@ type adzanData struct {
 	Ashr     string `json:"asr"`
 	Magrib   string `json:"magrib"`
 	Isha     string `json:"isha"`
+
+	// Asr of Syafi'i and Hanafi, only when dualAsr is requested
+	AshrSyafi  string `json:"asrSyafi,omitempty"`
+	AshrHanafi string `json:"asrHanafi,omitempty"`
+
+	Midnight  string `json:"midnight"`
+	LastThird string `json:"lastThird"`
+
+	// Reason of prayers that aren't calculated from the sun position
+	Status map[string]string `json:"status,omitempty"`
+
+	HighLatitudeRule highLatitudeRuleData `json:"highLatitudeRule"`
+
+	Iqamah map[string]string `json:"iqamah,omitempty"`
+
+	// Sessions of Jumu'ah prayer on Friday
+	Jumuah []jumuahData `json:"jumuah,omitempty"`
+}
+
+type jumuahData struct {
+	Khutbah string `json:"khutbah"`
+	Prayer  string `json:"prayer"`
+}
+
+type highLatitudeRuleData struct {
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+// Options of prayer times that given by query parameters
+type adzanOptions struct {
+	// Method that requested by user, otherwise it is chosen by the time zone
+	method *calc.CalculationMethod
+
+	// Rule that requested by user, otherwise it is recommended per date
+	highLatitudeRule *calc.HighLatitudeRule
+
+	iqamahRules []calc.IqamahRule
+
+	// Sessions that requested by user, otherwise the default session is used
+	jumuahRules []calc.JumuahRule
+
+	// Shadow factor of Asr that requested by user, otherwise Asr is Syafi'i
+	shadowFactor float64
+
+	// Return Asr of both Syafi'i and Hanafi
+	dualAsr bool
+}
+
+// Name of prayers in iqamah parameters (ex: iqamahFajr, fridayIqamahDhuhr)
+var iqamahParameterName = map[calc.Prayer]string{
+	calc.FAJR:   "Fajr",
+	calc.DHUHR:  "Dhuhr",
+	calc.ASR:    "Asr",
+	calc.MAGRIB: "Magrib",
+	calc.ISHA:   "Isha",
+}
+
+var highLatitudeRuleName = map[calc.HighLatitudeRule]string{
+	calc.NONE:                         "none",
+	calc.MIDDLE_OF_THE_NIGHT:          "middleOfTheNight",
+	calc.ONE_SEVENTH_OF_THE_NIGHT:     "oneSeventhOfTheNight",
+	calc.ANGLE_BASED_METHOD:           "angleBased",
+	calc.NEAREST_LATITUDE:             "nearestLatitude",
+	calc.NEAREST_DAY:                  "nearestDay",
+	calc.ONE_SEVENTH_WHEN_UNREACHABLE: "oneSeventhWhenUnreachable",
+}
+
+var prayerName = map[calc.Prayer]string{
+	calc.IMSAK:   "imsak",
+	calc.FAJR:    "fajr",
+	calc.SUNRISE: "sunrise",
+	calc.DHUHR:   "dhuhr",
+	calc.ASR:     "asr",
+	calc.MAGRIB:  "magrib",
+	calc.ISHA:    "isha",
+
+	calc.MIDNIGHT:   "midnight",
+	calc.LAST_THIRD: "lastThird",
+}
+
+var prayerStatusName = map[calc.PrayerStatus]string{
+	calc.CALCULATED:           "calculated",
+	calc.ESTIMATED:            "estimated",
+	calc.SUN_NEVER_RISES:      "the sun never rises",
+	calc.SUN_NEVER_SETS:       "the sun never sets",
+	calc.TWILIGHT_NOT_REACHED: "twilight angle is not reached",
+}
+
+var indonesianTimezone = map[string]bool{
+	"Asia/Jakarta":   true,
+	"Asia/Pontianak": true,
+	"Asia/Makassar":  true,
+	"Asia/Jayapura":  true,
+}
+
+var malaysianTimezone = map[string]bool{
+	"Asia/Kuala_Lumpur": true,
+	"Asia/Kuching":      true,
+}
+
+func validateYearAndMonthParameter(rawYear string, rawMonth string) bool {
+	yearR, _ := regexp.Compile("^[12][0-9]{3}$")
+	monthR, _ := regexp.Compile(`^(?:1[0-2]|0?[1-9])$`)
+
+	if !yearR.MatchString(rawYear) {
+		return false
+	}
+	if !monthR.MatchString(rawMonth) {
+		return false
+	}
+	return true
+}
+
+func getCoordinate(r *http.Request) (*utils.Coordinates, error) {
+	rawLat := r.URL.Query().Get("lat")
+	rawLng := r.URL.Query().Get("lng")
+
+	if rawLat == "" || rawLng == "" {
+		return nil, fmt.Errorf("please input coordinate of location")
+	}
+
+	coordinate, err := convertCoordinateToFloat64(rawLat, rawLng)
+	if err != nil {
+		return nil, err
+	}
+
+	rawElevation := r.URL.Query().Get("elevation")
+	if rawElevation == "" {
+		return coordinate, nil
+	}
+	elevation, err := strconv.ParseFloat(rawElevation, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid elevation. elevation must be in meters (ex: 120)")
+	}
+	return coordinate.SetElevation(elevation)
+}
+
+func getAdzanOptions(r *http.Request) (*adzanOptions, error) {
+	options := &adzanOptions{}
+
+	method, err := getCalculationMethod(r)
+	if err != nil {
+		return nil, err
+	}
+	options.method = method
+
+	rawRule := r.URL.Query().Get("highLatitudeRule")
+	if rawRule != "" {
+		for rule, name := range highLatitudeRuleName {
+			if name == rawRule {
+				requestedRule := rule
+				options.highLatitudeRule = &requestedRule
+				break
+			}
+		}
+		if options.highLatitudeRule == nil {
+			return nil, fmt.Errorf("invalid highLatitudeRule (ex: nearestLatitude, oneSeventhOfTheNight)")
+		}
+	}
+
+	iqamahRules, err := getIqamahRules(r)
+	if err != nil {
+		return nil, err
+	}
+	options.iqamahRules = iqamahRules
+
+	jumuahRules, err := getJumuahRules(r)
+	if err != nil {
+		return nil, err
+	}
+	options.jumuahRules = jumuahRules
+
+	rawShadowFactor := r.URL.Query().Get("shadowFactor")
+	if rawShadowFactor != "" {
+		shadowFactor, err := strconv.ParseFloat(rawShadowFactor, 64)
+		if err != nil || shadowFactor <= 0 {
+			return nil, fmt.Errorf("invalid shadowFactor. it must be a positive number (ex: 1.5)")
+		}
+		options.shadowFactor = shadowFactor
+	}
+
+	rawDualAsr := r.URL.Query().Get("dualAsr")
+	if rawDualAsr != "" {
+		dualAsr, err := strconv.ParseBool(rawDualAsr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dualAsr. it must be true or false")
+		}
+		options.dualAsr = dualAsr
+	}
+	return options, nil
+}
+
+// Parse iqamah rule from minutes after adhan (ex: 20) or fixed time (ex: 20:00)
+func parseIqamahRule(prayer calc.Prayer, value string, roundUp int8) (*calc.IqamahRule, error) {
+	rule := &calc.IqamahRule{Prayer: prayer, RoundUp: roundUp}
+	if strings.Contains(value, ":") {
+		fixed, err := time.Parse("15:04", value)
+		if err != nil {
+			return nil, err
+		}
+		rule.FixedTime = &utils.TimeComponents{Hours: int16(fixed.Hour()), Minutes: int16(fixed.Minute())}
+		return rule, nil
+	}
+	offset, err := strconv.ParseInt(value, 10, 8)
+	if err != nil || offset < 0 {
+		return nil, fmt.Errorf("invalid offset")
+	}
+	rule.Offset = int8(offset)
+	return rule, nil
+}
+
+// Parse months of the seasonal iqamah from comma separated months (ex: 11,12,1,2)
+func getIqamahSeasonMonths(r *http.Request) ([]time.Month, error) {
+	rawMonths := r.URL.Query().Get("iqamahSeasonMonths")
+	if rawMonths == "" {
+		return nil, nil
+	}
+	var months []time.Month
+	for _, rawMonth := range strings.Split(rawMonths, ",") {
+		month, err := strconv.Atoi(rawMonth)
+		if err != nil || month < 1 || month > 12 {
+			return nil, fmt.Errorf("invalid iqamahSeasonMonths. it must be comma separated months (ex: 11,12,1,2)")
+		}
+		months = append(months, time.Month(month))
+	}
+	return months, nil
+}
+
+func getIqamahRules(r *http.Request) ([]calc.IqamahRule, error) {
+	var roundUp int8
+	rawRound := r.URL.Query().Get("iqamahRound")
+	if rawRound != "" {
+		round, err := strconv.ParseInt(rawRound, 10, 8)
+		if err != nil || round < 0 {
+			return nil, fmt.Errorf("invalid iqamahRound. it must be in minutes (ex: 5)")
+		}
+		roundUp = int8(round)
+	}
+
+	seasonMonths, err := getIqamahSeasonMonths(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []calc.IqamahRule
+	for prayer, name := range iqamahParameterName {
+		for _, prefix := range []string{"iqamah", "fridayIqamah", "ramadanIqamah", "seasonIqamah"} {
+			value := r.URL.Query().Get(prefix + name)
+			if value == "" {
+				continue
+			}
+			rule, err := parseIqamahRule(prayer, value, roundUp)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"invalid %v. it must be minutes after adhan (ex: 20) or time (ex: 20:00)", prefix+name,
+				)
+			}
+			rule.Friday = prefix == "fridayIqamah"
+			rule.Ramadan = prefix == "ramadanIqamah"
+			if prefix == "seasonIqamah" {
+				if seasonMonths == nil {
+					return nil, fmt.Errorf("%v needs iqamahSeasonMonths (ex: 11,12,1,2)", prefix+name)
+				}
+				rule.Months = seasonMonths
+			}
+			rules = append(rules, *rule)
+		}
+	}
+	return rules, nil
+}
+
+// Parse Jumu'ah sessions from comma separated minutes after Dhuhr (ex: 10)
+// or fixed time of khutbah (ex: 12:00), ex: jumuah=10,13:00&khutbahDuration=20
+func getJumuahRules(r *http.Request) ([]calc.JumuahRule, error) {
+	rawSessions := r.URL.Query().Get("jumuah")
+	rawDuration := r.URL.Query().Get("khutbahDuration")
+	if rawSessions == "" && rawDuration == "" {
+		return nil, nil
+	}
+	if rawSessions == "" {
+		rawSessions = "0"
+	}
+
+	var duration int8 = 15
+	if rawDuration != "" {
+		value, err := strconv.ParseInt(rawDuration, 10, 8)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid khutbahDuration. it must be in minutes (ex: 20)")
+		}
+		duration = int8(value)
+	}
+
+	var rules []calc.JumuahRule
+	for _, rawSession := range strings.Split(rawSessions, ",") {
+		rule := calc.JumuahRule{KhutbahDuration: duration}
+		if strings.Contains(rawSession, ":") {
+			fixed, err := time.Parse("15:04", rawSession)
+			if err != nil {
+				return nil, fmt.Errorf("invalid jumuah session time (ex: 12:00)")
+			}
+			rule.FixedTime = &utils.TimeComponents{Hours: int16(fixed.Hour()), Minutes: int16(fixed.Minute())}
+		} else {
+			offset, err := strconv.ParseInt(rawSession, 10, 8)
+			if err != nil || offset < 0 {
+				return nil, fmt.Errorf("invalid jumuah session. it must be minutes after dhuhr (ex: 10) or time (ex: 12:00)")
+			}
+			rule.Offset = int8(offset)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func getCalculationParameters(timezone *time.Location, options *adzanOptions) *calc.CalculationParameters {
+	if options.method != nil {
+		return calc.GetCalculationMethod(*options.method)
+	}
+	if indonesianTimezone[timezone.String()] {
+		return calc.GetCalculationMethod(calc.KEMENAG)
+	} else if malaysianTimezone[timezone.String()] {
+		return calc.GetCalculationMethod(calc.JAKIM)
+	} else if timezone.String() == "Asia/Singapore" {
+		return calc.GetCalculationMethod(calc.SINGAPORE)
+	}
+	return calc.GetCalculationMethod(calc.MUSLIM_WORLD_LEAGUE)
+}
+
+func getAdzanData(
+	date time.Time,
+	coordinate *utils.Coordinates,
+	timezone *time.Location,
+	options *adzanOptions,
+) (*adzanData, int, error) {
+	param := getCalculationParameters(timezone, options)
+	dateComponent := utils.NewDateComponents(date)
+
+	recommendation := &calc.HighLatitudeRecommendation{Reason: "requested"}
+	if options.highLatitudeRule != nil {
+		recommendation.Rule = *options.highLatitudeRule
+	} else {
+		recommendation = calc.RecommendHighLatitudeRule(coordinate, dateComponent, param)
+	}
+	param.SetHighLatitudeRule(recommendation.Rule)
+	if options.jumuahRules != nil {
+		param.SetJumuahRules(options.jumuahRules...)
+	}
+	param.SetShadowFactor(options.shadowFactor).SetDualAsr(options.dualAsr)
+
+	adzan, err := calc.NewPrayerTimes(coordinate, dateComponent, param)
+	if err != nil {
+		return nil, 500, err
+	}
+	if err := adzan.SetTimeZone(timezone.String()); err != nil {
+		return nil, 500, err
+	}
+
+	var iqamah *calc.IqamahTimes
+	if len(options.iqamahRules) > 0 {
+		iqamah, err = calc.NewIqamahTimes(adzan, options.iqamahRules)
+		if err != nil {
+			return nil, 400, err
+		}
+	}
+
+	jumuah, err := adzan.JumuahSessions()
+	if err != nil {
+		return nil, 400, err
+	}
+
+	data := newAdzanData(date, timezone, adzan, recommendation, iqamah, jumuah)
+	return &data, 200, nil
+}
+
+// Format time of a prayer. Prayer that doesn't occur on the date is empty
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("15:04")
 }
 
-// func validateYearAndMonthParameter(rawYear string, rawMonth string) bool {
-// 	yearR, _ := regexp.Compile("^[12][0-9]{3}$")
-// 	monthR, _ := regexp.Compile(`\b(?:1[0-2]|[1-9])\b`)
-
-// 	if !yearR.MatchString(rawYear) {
-// 		return false
-// 	}
-// 	if !monthR.MatchString(rawMonth) {
-// 		return false
-// 	}
-// 	return true
-// }
-
-// func getTimeZoneAndCurrentDate(coordinate *utils.Coordinates) (*utils.TimeZone, *time.Time, int, error) {
-// 	timezone, statusCode, err := utils.GetTimeZone(coordinate.Latitude, coordinate.Longitude)
-// 	if err != nil {
-// 		return nil, nil, statusCode, err
-// 	}
-
-// 	currentDate, err := time.Parse("2006-01-02 15:04", timezone.Time)
-// 	if err != nil {
-// 		return nil, nil, 500, err
-// 	}
-
-// 	return timezone, &currentDate, 200, nil
-// }
-
-// func getAdzanData(date *time.Time, coordinate *utils.Coordinates, timezone *utils.TimeZone) (*calc.PrayerTimes, int, error) {
-// 	param := calc.GetCalculationMethod(calc.MUSLIM_WORLD_LEAGUE).SetMazhab(calc.SYAFI)
-// 	if timezone.CountryCode == "ID" {
-// 		param = calc.GetCalculationMethod(calc.KEMENAG).SetMazhab(calc.SYAFI)
-// 	} else if timezone.CountryCode == "MY" || timezone.CountryCode == "SG" {
-// 		param = calc.GetCalculationMethod(calc.MUHAMMADIYAH).SetMazhab(calc.SYAFI)
-// 	}
-
-// 	adzan, err := calc.NewPrayerTimes(coordinate, utils.NewDateComponents(*date), param)
-// 	if err != nil {
-// 		return nil, 500, err
-// 	}
-// 	adzan.SetTimeZone(timezone.TimezoneId)
-
-// 	return adzan, 200, nil
-// }
-
-// func TodayAdzan(w http.ResponseWriter, r *http.Request) {
-// 	w.Header().Set("Content-Type", "application/json")
-// 	rawLat := r.URL.Query().Get("lat")
-// 	rawLng := r.URL.Query().Get("lng")
-
-// 	if rawLat == "" || rawLng == "" {
-// 		http.Error(w, "please input coordinate of location", 400)
-// 		return
-// 	}
-
-// 	coordinate, err := convertCoordinateToFloat64(rawLat, rawLng)
-// 	if err != nil {
-// 		http.Error(w, err.Error(), 400)
-// 		return
-// 	}
-
-// 	timezone, currentDate, statusCode, err := getTimeZoneAndCurrentDate(coordinate)
-// 	if err != nil {
-// 		http.Error(w, err.Error(), statusCode)
-// 		return
-// 	}
-
-// 	adzan, statusCode, err := getAdzanData(currentDate, coordinate, timezone)
-// 	if err != nil {
-// 		http.Error(w, err.Error(), statusCode)
-// 		return
-// 	}
-
-// 	hijrDate := calc.ConvertGeorgianToHijr(*utils.NewDateComponents(*currentDate))
-// 	formattedDate := currentDate.Format("January 02, 2006")
-// 	if timezone.CountryCode == "ID" {
-// 		formattedDate = currentDate.Format("02 January 2006")
-// 	}
-
-// 	data := adzanData{
-// 		Date: formattedDate,
-// 		HijrDate: fmt.Sprintf(
-// 			"%v %v %v",
-// 			hijrDate.Day,
-// 			hijrMontName[int(hijrDate.Month)],
-// 			hijrDate.Year,
-// 		),
-// 		Imsak:   adzan.Imsak.Format("15:04"),
-// 		Fajr:    adzan.Fajr.Format("15:04"),
-// 		Sunrise: adzan.Sunrise.Format("15:04"),
-// 		Dhuhr:   adzan.Dhuhr.Format("15:04"),
-// 		Ashr:    adzan.Ashr.Format("15:04"),
-// 		Magrib:  adzan.Magrib.Format("15:04"),
-// 		Isha:    adzan.Isha.Format("15:04"),
-// 	}
-
-// 	jsonData, err := json.Marshal(utils.SuccessResponse(data))
-// 	if err != nil {
-// 		http.Error(w, err.Error(), 500)
-// 	}
-// 	fmt.Fprint(w, string(jsonData))
-// }
-
-// func MonthlyAdzan(w http.ResponseWriter, r *http.Request) {
-// 	w.Header().Set("Content-Type", "application/json")
-// 	rawLat := r.URL.Query().Get("lat")
-// 	rawLng := r.URL.Query().Get("lng")
-// 	rawYear := r.URL.Query().Get("year")
-// 	rawMonth := r.URL.Query().Get("month")
-
-// 	if rawLat == "" || rawLng == "" {
-// 		http.Error(w, "please input coordinate of location", 400)
-// 		return
-// 	}
-
-// 	if !validateYearAndMonthParameter(rawYear, rawMonth) {
-// 		http.Error(w, "please input valid year and month (ex: 2024 for year and 05 or 5 for month)", 400)
-// 		return
-// 	}
-
-// 	coordinate, err := convertCoordinateToFloat64(rawLat, rawLng)
-// 	if err != nil {
-// 		http.Error(w, err.Error(), 400)
-// 		return
-// 	}
-
-// 	timezone, _, statusCode, err := getTimeZoneAndCurrentDate(coordinate)
-// 	if err != nil {
-// 		http.Error(w, err.Error(), statusCode)
-// 		return
-// 	}
-
-// 	if len(rawMonth) == 1 {
-// 		rawMonth = "0" + rawMonth
-// 	}
-// 	startDate, _ := time.Parse("2006-01-02", rawYear+"-"+rawMonth+"-01")
-// 	var prayerTimes []adzanData
-
-// 	for d := startDate; d.Month() == startDate.Month(); d = d.AddDate(0, 0, 1) {
-// 		prayerTime, statusCode, err := getAdzanData(&d, coordinate, timezone)
-// 		if err != nil {
-// 			http.Error(w, err.Error(), statusCode)
-// 			return
-// 		}
-
-// 		hijrDate := calc.ConvertGeorgianToHijr(*utils.NewDateComponents(d))
-// 		formattedDate := d.Format("January 02, 2006")
-// 		if timezone.CountryCode == "ID" {
-// 			formattedDate = d.Format("02 January 2006")
-// 		}
-
-// 		prayerTimes = append(prayerTimes, adzanData{
-// 			Date: formattedDate,
-// 			HijrDate: fmt.Sprintf(
-// 				"%v %v %v",
-// 				hijrDate.Day,
-// 				hijrMontName[int(hijrDate.Month)],
-// 				hijrDate.Year,
-// 			),
-// 			Imsak:   prayerTime.Imsak.Format("15:04"),
-// 			Fajr:    prayerTime.Fajr.Format("15:04"),
-// 			Sunrise: prayerTime.Sunrise.Format("15:04"),
-// 			Dhuhr:   prayerTime.Dhuhr.Format("15:04"),
-// 			Ashr:    prayerTime.Ashr.Format("15:04"),
-// 			Magrib:  prayerTime.Magrib.Format("15:04"),
-// 			Isha:    prayerTime.Isha.Format("15:04"),
-// 		})
-// 	}
-
-// 	jsonData, err := json.Marshal(utils.SuccessResponse(prayerTimes))
-// 	if err != nil {
-// 		http.Error(w, err.Error(), 500)
-// 	}
-// 	fmt.Fprint(w, string(jsonData))
-// }
+func newAdzanData(
+	date time.Time,
+	timezone *time.Location,
+	adzan *calc.PrayerTimes,
+	recommendation *calc.HighLatitudeRecommendation,
+	iqamah *calc.IqamahTimes,
+	jumuah []calc.JumuahSession,
+) adzanData {
+	hijrDate := calc.ConvertGeorgianToHijr(*utils.NewDateComponents(date))
+	formattedDate := date.Format("January 02, 2006")
+	if indonesianTimezone[timezone.String()] {
+		formattedDate = date.Format("02 January 2006")
+	}
+
+	var status map[string]string
+	for prayer, prayerStatus := range adzan.Status {
+		if prayerStatus == calc.CALCULATED {
+			continue
+		}
+		if status == nil {
+			status = map[string]string{}
+		}
+		status[prayerName[prayer]] = prayerStatusName[prayerStatus]
+	}
+
+	var iqamahTimes map[string]string
+	if iqamah != nil {
+		iqamahTimes = map[string]string{}
+		for prayer, t := range iqamah.Times {
+			iqamahTimes[prayerName[prayer]] = formatTime(t)
+		}
+	}
+
+	var jumuahSessions []jumuahData
+	for _, session := range jumuah {
+		jumuahSessions = append(jumuahSessions, jumuahData{
+			Khutbah: formatTime(session.Khutbah),
+			Prayer:  formatTime(session.Prayer),
+		})
+	}
+
+	return adzanData{
+		Date: formattedDate,
+		HijrDate: fmt.Sprintf(
+			"%v %v %v",
+			hijrDate.Day,
+			monthName[int(hijrDate.Month)],
+			hijrDate.Year,
+		),
+		Imsak:   formatTime(adzan.Imsak),
+		Fajr:    formatTime(adzan.Fajr),
+		Sunrise: formatTime(adzan.Sunrise),
+		Dhuhr:   formatTime(adzan.Dhuhr),
+		Ashr:    formatTime(adzan.Ashr),
+		Magrib:  formatTime(adzan.Magrib),
+		Isha:    formatTime(adzan.Isha),
+
+		AshrSyafi:  formatTime(adzan.AshrSyafi),
+		AshrHanafi: formatTime(adzan.AshrHanafi),
+
+		Midnight:  formatTime(adzan.Midnight),
+		LastThird: formatTime(adzan.LastThird),
+		Status:    status,
+		HighLatitudeRule: highLatitudeRuleData{
+			Rule:   highLatitudeRuleName[recommendation.Rule],
+			Reason: recommendation.Reason,
+		},
+		Iqamah: iqamahTimes,
+		Jumuah: jumuahSessions,
+	}
+}
+
+func TodayAdzan(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	coordinate, err := getCoordinate(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	options, err := getAdzanOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	timezone, err := utils.GetTimeZone(coordinate.Latitude, coordinate.Longitude)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+	currentDate := time.Now().In(timezone)
+
+	adzan, statusCode, err := getAdzanData(currentDate, coordinate, timezone, options)
+	if err != nil {
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	jsonData, err := json.Marshal(utils.SuccessResponse(adzan))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	fmt.Fprint(w, string(jsonData))
+}
+
+func MonthlyAdzan(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	rawYear := r.URL.Query().Get("year")
+	rawMonth := r.URL.Query().Get("month")
+
+	coordinate, err := getCoordinate(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	if !validateYearAndMonthParameter(rawYear, rawMonth) {
+		http.Error(w, "please input valid year and month (ex: 2024 for year and 05 or 5 for month)", 400)
+		return
+	}
+
+	options, err := getAdzanOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	timezone, err := utils.GetTimeZone(coordinate.Latitude, coordinate.Longitude)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	if len(rawMonth) == 1 {
+		rawMonth = "0" + rawMonth
+	}
+	startDate, _ := time.Parse("2006-01-02", rawYear+"-"+rawMonth+"-01")
+	var prayerTimes []adzanData
+
+	for d := startDate; d.Month() == startDate.Month(); d = d.AddDate(0, 0, 1) {
+		prayerTime, statusCode, err := getAdzanData(d, coordinate, timezone, options)
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+		prayerTimes = append(prayerTimes, *prayerTime)
+	}
+
+	jsonData, err := json.Marshal(utils.SuccessResponse(prayerTimes))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	fmt.Fprint(w, string(jsonData))
+}
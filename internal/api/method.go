@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/taufiq30s/adzan/internal/calc"
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+type methodData struct {
+	ID             int     `json:"id"`
+	Key            string  `json:"key"`
+	Name           string  `json:"name"`
+	Region         string  `json:"region"`
+	FajrAngle      float32 `json:"fajrAngle"`
+	IshaAngle      float32 `json:"ishaAngle,omitempty"`
+	IshaInterval   int8    `json:"ishaInterval,omitempty"`
+	MaghribAngle   float32 `json:"maghribAngle,omitempty"`
+	MidnightMethod string  `json:"midnightMethod"`
+}
+
+var midnightMethodName = map[calc.MidnightMethod]string{
+	calc.SUNSET_TO_SUNRISE: "sunsetToSunrise",
+	calc.SUNSET_TO_FAJR:    "sunsetToFajr",
+}
+
+// Get method from its key (ex: kemenag, muslimWorldLeague)
+func getCalculationMethod(r *http.Request) (*calc.CalculationMethod, error) {
+	rawMethod := r.URL.Query().Get("method")
+	if rawMethod == "" {
+		return nil, nil
+	}
+	definition, ok := calc.GetMethodDefinitionByKey(rawMethod)
+	if !ok {
+		return nil, fmt.Errorf("invalid method. see /methods for the list of methods")
+	}
+	return &definition.ID, nil
+}
+
+func Methods(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var methods []methodData
+	for _, definition := range calc.MethodDefinitions() {
+		methods = append(methods, methodData{
+			ID:             int(definition.ID),
+			Key:            definition.Key,
+			Name:           definition.Name,
+			Region:         definition.Region,
+			FajrAngle:      definition.FajrAngle,
+			IshaAngle:      definition.IshaAngle,
+			IshaInterval:   definition.IshaInterval,
+			MaghribAngle:   definition.MaghribAngle,
+			MidnightMethod: midnightMethodName[definition.MidnightMethod],
+		})
+	}
+
+	jsonData, err := json.Marshal(utils.SuccessResponse(methods))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	fmt.Fprint(w, string(jsonData))
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/calc"
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+type qiblaData struct {
+	Bearing      float64 `json:"bearing"`
+	RhumbBearing float64 `json:"rhumbBearing"`
+
+	// Distance to the Kaaba in kilometers
+	Distance float64 `json:"distance"`
+}
+
+type rashdulQiblatData struct {
+	Date string `json:"date"`
+	Time string `json:"time"`
+
+	// The sun is above the Kaaba, otherwise it is the daily time of the location
+	Global bool `json:"global"`
+
+	// Direction of the shadow of a vertical object (towardQibla or awayFromQibla)
+	Shadow string `json:"shadow"`
+
+	// The sun is above the horizon of the location
+	Visible bool `json:"visible"`
+}
+
+// Maximum days of rashdul qiblat events in a request
+const maxRashdulQiblatDays = 366
+
+func Qibla(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	coordinate, err := getCoordinate(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	qibla := calc.Qibla(coordinate)
+	jsonData, err := json.Marshal(utils.SuccessResponse(qiblaData{
+		Bearing:      qibla.Bearing,
+		RhumbBearing: qibla.RhumbBearing,
+		Distance:     qibla.Distance,
+	}))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	fmt.Fprint(w, string(jsonData))
+}
+
+func RashdulQiblat(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	coordinate, err := getCoordinate(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	days := 30
+	rawDays := r.URL.Query().Get("days")
+	if rawDays != "" {
+		days, err = strconv.Atoi(rawDays)
+		if err != nil || days < 1 || days > maxRashdulQiblatDays {
+			http.Error(w, fmt.Sprintf("invalid days. it must be between 1 and %v", maxRashdulQiblatDays), 400)
+			return
+		}
+	}
+
+	timezone, err := utils.GetTimeZone(coordinate.Latitude, coordinate.Longitude)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	events, err := calc.RashdulQiblatEvents(coordinate, time.Now(), days, nil)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	var data []rashdulQiblatData
+	for _, event := range events {
+		shadow := "awayFromQibla"
+		if event.TowardQibla {
+			shadow = "towardQibla"
+		}
+		local := event.Time.In(timezone)
+		data = append(data, rashdulQiblatData{
+			Date:    local.Format("2006-01-02"),
+			Time:    local.Format("15:04:05"),
+			Global:  event.Global,
+			Shadow:  shadow,
+			Visible: event.Visible,
+		})
+	}
+
+	jsonData, err := json.Marshal(utils.SuccessResponse(data))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	fmt.Fprint(w, string(jsonData))
+}
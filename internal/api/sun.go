@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/calc"
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+type sunData struct {
+	Time             string  `json:"time"`
+	Altitude         float64 `json:"altitude"`
+	ApparentAltitude float64 `json:"apparentAltitude"`
+	Depression       float64 `json:"depression"`
+	Azimuth          float64 `json:"azimuth"`
+	HourAngle        float64 `json:"hourAngle"`
+	Declination      float64 `json:"declination"`
+	RightAscension   float64 `json:"rightAscension"`
+	EquationOfTime   float64 `json:"equationOfTime"`
+}
+
+// Get the instant from RFC 3339 (ex: 2024-05-01T04:30:00+07:00)
+// or local time of the location (ex: 2024-05-01T04:30), otherwise it is now
+func getInstant(r *http.Request, timezone *time.Location) (time.Time, error) {
+	rawTime := r.URL.Query().Get("time")
+	if rawTime == "" {
+		return time.Now().In(timezone), nil
+	}
+	if t, err := time.Parse(time.RFC3339, rawTime); err == nil {
+		return t.In(timezone), nil
+	}
+	t, err := time.ParseInLocation("2006-01-02T15:04", rawTime, timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time (ex: 2024-05-01T04:30 or 2024-05-01T04:30:00+07:00)")
+	}
+	return t, nil
+}
+
+func SunPosition(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	coordinate, err := getCoordinate(r)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	timezone, err := utils.GetTimeZone(coordinate.Latitude, coordinate.Longitude)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	instant, err := getInstant(r, timezone)
+	if err != nil {
+		http.Error(w, err.Error(), 400)
+		return
+	}
+
+	position := calc.SunPosition(coordinate, instant, nil)
+	jsonData, err := json.Marshal(utils.SuccessResponse(sunData{
+		Time:             position.Time.Format(time.RFC3339),
+		Altitude:         position.Altitude,
+		ApparentAltitude: position.ApparentAltitude,
+		Depression:       position.Depression,
+		Azimuth:          position.Azimuth,
+		HourAngle:        position.HourAngle,
+		Declination:      position.Declination,
+		RightAscension:   position.RightAscension,
+		EquationOfTime:   position.EquationOfTime,
+	}))
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	fmt.Fprint(w, string(jsonData))
+}
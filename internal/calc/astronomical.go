@@ -142,6 +142,19 @@ func ApparentObliquityOfTheEcliptic(t float64, meanObliquityEcliptic float64) fl
 	return meanObliquityEcliptic + (0.00256 * math.Cos(utils.Radians(O)))
 }
 
+// Horizon Dip
+// returns the dip of the visible horizon in degrees
+// for an observer at the given elevation in meters.
+// It is zero for observer at or below sea level.
+//
+// Reference: The Nautical Almanac, Dip of the sea horizon
+func HorizonDip(elevation float64) float64 {
+	if elevation <= 0 {
+		return 0
+	}
+	return (1.76 / 60) * math.Sqrt(elevation)
+}
+
 // Altitude of Celestial Body
 // returns the altitude of the celestial body
 func AltitudeOfCelestialBody(observerLatitude float64, declination float64, H float64) float64 {
@@ -161,17 +174,34 @@ func ApproximateTransit(L float64, siderealTime float64, rightAscension float64)
 	return utils.NormalizeWithBound((rightAscension+Lw-siderealTime)/360, 1)
 }
 
+// Corrected Transit
+// returns the time of transit in hours (UT)
+//
+// Given 'siderealTime' at 0h UT, the sun position at 0h TD
+// and 'deltaT' (TT - UT) in seconds
+//
+// Reference: Astronomical Algorithm Chapter 15 Page 103
 func CorrectedTransit(approximateTransit float64, L float64, siderealTime float64,
 	rightAscension float64, prevRightAscension float64,
-	nextRightAscension float64) float64 {
+	nextRightAscension float64, deltaT float64) float64 {
 	Lw := L * -1
+	n := approximateTransit + (deltaT / 86400)
 	theta := utils.UnwindAngle(siderealTime + (360.985647 * approximateTransit))
-	a := utils.UnwindAngle(utils.InterpolateAngles(rightAscension, prevRightAscension, nextRightAscension, approximateTransit))
+	a := utils.UnwindAngle(utils.InterpolateAngles(rightAscension, prevRightAscension, nextRightAscension, n))
 	H := utils.ClosestAngle(theta - Lw - a)
 	dm := H / -360
 	return (approximateTransit + dm) * 24
 }
 
+// Corrected Hour Angle
+// returns the time in hours (UT) when the sun is at the given angle.
+// It returns ErrSunNeverRises when the sun stays below the angle
+// and ErrSunNeverSets when the sun stays above the angle on the date.
+//
+// Given 'siderealTime' at 0h UT, the sun position at 0h TD
+// and 'deltaT' (TT - UT) in seconds
+//
+// Reference: Astronomical Algorithm Chapter 15 Page 103
 func CorrectedHourAngle(
 	approximateTransit float64,
 	angle float64,
@@ -184,18 +214,26 @@ func CorrectedHourAngle(
 	declination float64,
 	prevDeclination float64,
 	nextDeclination float64,
-) float64 {
+	deltaT float64,
+) (float64, error) {
 	Lw := coordinate.Longitude * -1
 	term1 := math.Sin(utils.Radians(angle)) - (math.Sin(utils.Radians(coordinate.Latitude)) * math.Sin(utils.Radians(declination)))
 	term2 := math.Cos(utils.Radians(coordinate.Latitude)) * math.Cos(utils.Radians(declination))
-	H0 := utils.Degrees(math.Acos(term1 / term2))
+	cosH0 := term1 / term2
+	if cosH0 > 1 {
+		return math.NaN(), ErrSunNeverRises
+	} else if cosH0 < -1 {
+		return math.NaN(), ErrSunNeverSets
+	}
+	H0 := utils.Degrees(math.Acos(cosH0))
 	m := approximateTransit + (H0 / 360)
 	if !afterTransit {
 		m = approximateTransit - (H0 / 360)
 	}
+	n := m + (deltaT / 86400)
 	theta := utils.UnwindAngle(siderealTime + (360.985647 * m))
-	a := utils.UnwindAngle(utils.InterpolateAngles(rightAscension, prevRightAscension, nextRightAscension, m))
-	delta := utils.Interpolate(declination, prevDeclination, nextDeclination, m)
+	a := utils.UnwindAngle(utils.InterpolateAngles(rightAscension, prevRightAscension, nextRightAscension, n))
+	delta := utils.Interpolate(declination, prevDeclination, nextDeclination, n)
 	H := theta - Lw - a
 	h := AltitudeOfCelestialBody(
 		coordinate.Latitude,
@@ -205,5 +243,5 @@ func CorrectedHourAngle(
 	dm := (h - angle) / (360 * math.Cos(utils.Radians(delta)) *
 		math.Cos(utils.Radians(coordinate.Latitude)) *
 		math.Sin(utils.Radians(H)))
-	return (m + dm) * 24
+	return (m + dm) * 24, nil
 }
@@ -136,15 +136,22 @@ func ConvertGeorgianToHijr(date utils.DateComponents) utils.DateComponents {
 	}
 }
 
+// Julian Day
+// returns the julian day (universal time) of the given date
+// where 'hours' is the time of the day in UT
+//
+// Reference: Astronomical Algorithm Chapter 7 Page 61
 func GetJulianDay(date *utils.DateComponents, hours float64) float64 {
 	var A, B float64
-	if date.Month < 3 {
-		date.Year--
-		date.Month += 12
+	year := float64(date.Year)
+	month := float64(date.Month)
+	if month < 3 {
+		year--
+		month += 12
 	}
-	A = math.Floor(float64(date.Year) / 100)
+	A = math.Floor(year / 100)
 	B = 2 - A + math.Floor(A/4)
-	return float64(int(365.25*float64(date.Year+4716))+int(30.6001*float64(date.Month+1))) + float64(date.Day) + B - 1524.5
+	return math.Floor(365.25*(year+4716)) + math.Floor(30.6001*(month+1)) + float64(date.Day) + (hours / 24) + B - 1524.5
 }
 
 func GetJulianCentury(jd float64) float64 {
@@ -0,0 +1,99 @@
+package calc
+
+import "math"
+
+/*
+Delta T
+
+The difference between Terrestrial (Dynamical) Time and Universal Time.
+Positions of the sun and the moon are calculated in dynamical time (JDE),
+while the civil time and the sidereal time are based on universal time (JD).
+
+Reference: Astronomical Algorithm Chapter 10 Page 77
+Reference: https://eclipse.gsfc.nasa.gov/SEhelp/deltatpoly2004.html
+*/
+
+// Delta T
+// returns TT - UT in seconds
+//
+// Given 'year', the decimal year (ex: 2024.5 for the middle of 2024)
+// Uses polynomial expressions of Espenak and Meeus
+func DeltaT(year float64) float64 {
+	switch {
+	case year < -500:
+		u := (year - 1820) / 100
+		return -20 + (32 * math.Pow(u, 2))
+	case year < 500:
+		u := year / 100
+		return 10583.6 - (1014.41 * u) + (33.78311 * math.Pow(u, 2)) -
+			(5.952053 * math.Pow(u, 3)) - (0.1798452 * math.Pow(u, 4)) +
+			(0.022174192 * math.Pow(u, 5)) + (0.0090316521 * math.Pow(u, 6))
+	case year < 1600:
+		u := (year - 1000) / 100
+		return 1574.2 - (556.01 * u) + (71.23472 * math.Pow(u, 2)) +
+			(0.319781 * math.Pow(u, 3)) - (0.8503463 * math.Pow(u, 4)) -
+			(0.005050998 * math.Pow(u, 5)) + (0.0083572073 * math.Pow(u, 6))
+	case year < 1700:
+		t := year - 1600
+		return 120 - (0.9808 * t) - (0.01532 * math.Pow(t, 2)) + (math.Pow(t, 3) / 7129)
+	case year < 1800:
+		t := year - 1700
+		return 8.83 + (0.1603 * t) - (0.0059285 * math.Pow(t, 2)) +
+			(0.00013336 * math.Pow(t, 3)) - (math.Pow(t, 4) / 1174000)
+	case year < 1860:
+		t := year - 1800
+		return 13.72 - (0.332447 * t) + (0.0068612 * math.Pow(t, 2)) +
+			(0.0041116 * math.Pow(t, 3)) - (0.00037436 * math.Pow(t, 4)) +
+			(0.0000121272 * math.Pow(t, 5)) - (0.0000001699 * math.Pow(t, 6)) +
+			(0.000000000875 * math.Pow(t, 7))
+	case year < 1900:
+		t := year - 1860
+		return 7.62 + (0.5737 * t) - (0.251754 * math.Pow(t, 2)) +
+			(0.01680668 * math.Pow(t, 3)) - (0.0004473624 * math.Pow(t, 4)) +
+			(math.Pow(t, 5) / 233174)
+	case year < 1920:
+		t := year - 1900
+		return -2.79 + (1.494119 * t) - (0.0598939 * math.Pow(t, 2)) +
+			(0.0061966 * math.Pow(t, 3)) - (0.000197 * math.Pow(t, 4))
+	case year < 1941:
+		t := year - 1920
+		return 21.20 + (0.84493 * t) - (0.076100 * math.Pow(t, 2)) + (0.0020936 * math.Pow(t, 3))
+	case year < 1961:
+		t := year - 1950
+		return 29.07 + (0.407 * t) - (math.Pow(t, 2) / 233) + (math.Pow(t, 3) / 2547)
+	case year < 1986:
+		t := year - 1975
+		return 45.45 + (1.067 * t) - (math.Pow(t, 2) / 260) - (math.Pow(t, 3) / 718)
+	case year < 2005:
+		t := year - 2000
+		return 63.86 + (0.3345 * t) - (0.060374 * math.Pow(t, 2)) +
+			(0.0017275 * math.Pow(t, 3)) + (0.000651814 * math.Pow(t, 4)) +
+			(0.00002373599 * math.Pow(t, 5))
+	case year < 2050:
+		t := year - 2000
+		return 62.92 + (0.32217 * t) + (0.005589 * math.Pow(t, 2))
+	case year < 2150:
+		u := (year - 1820) / 100
+		return -20 + (32 * math.Pow(u, 2)) - (0.5628 * (2150 - year))
+	default:
+		u := (year - 1820) / 100
+		return -20 + (32 * math.Pow(u, 2))
+	}
+}
+
+// Decimal year of the given julian day
+func JulianDayToYear(jd float64) float64 {
+	return 2000 + ((jd - 2451545) / 365.25)
+}
+
+// Julian Ephemeris Day
+// returns the JDE (dynamical time) of the given JD (universal time)
+func JulianEphemerisDay(jd float64) float64 {
+	return jd + (DeltaT(JulianDayToYear(jd)) / 86400)
+}
+
+// Universal Julian Day
+// returns the JD (universal time) of the given JDE (dynamical time)
+func UniversalJulianDay(jde float64) float64 {
+	return jde - (DeltaT(JulianDayToYear(jde)) / 86400)
+}
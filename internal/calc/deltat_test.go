@@ -0,0 +1,28 @@
+package calc
+
+import (
+	"math"
+	"testing"
+)
+
+// Reference: https://eclipse.gsfc.nasa.gov/SEcat5/deltat.html
+func TestDeltaT(t *testing.T) {
+	tests := []struct {
+		year   float64
+		deltaT float64
+	}{
+		{1700, 8.8},
+		{1800, 13.7},
+		{1900, -2.7},
+		{1950, 29.1},
+		{1980, 50.5},
+		{2000, 63.8},
+		{2005, 64.7},
+	}
+
+	for _, tt := range tests {
+		if got := DeltaT(tt.year); math.Abs(got-tt.deltaT) > 0.5 {
+			t.Errorf("DeltaT(%v) = %v, want %v", tt.year, got, tt.deltaT)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package calc
+
+import "errors"
+
+var (
+	// The sun stays below the requested altitude for the whole day,
+	// ex: sunrise and sunset in polar night
+	ErrSunNeverRises = errors.New("the sun never rises on this date")
+
+	// The sun stays above the requested altitude for the whole day,
+	// ex: sunrise and sunset in midnight sun
+	ErrSunNeverSets = errors.New("the sun never sets on this date")
+
+	// The sun never reaches the twilight angle of Fajr or Isha
+	// and the time can't be estimated by the high latitude rule
+	ErrTwilightNotReached = errors.New("the twilight angle is not reached on this date")
+
+	// None of the prayers of a schedule occurs within a year,
+	// ex: Fajr and Isha in polar region without high latitude rule
+	ErrNoPrayerEvent = errors.New("no prayer occurs within a year")
+)
@@ -1,5 +1,14 @@
 package calc
 
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
 /*
 High Latitude Rule
 
@@ -34,11 +43,217 @@ const (
 	// For example, if the twilight angle for Isha is 15, then Isha begins at the end of the first quarter (15/60)
 	// of the night. Time for Fajr is calculated similarly.
 	ANGLE_BASED_METHOD
+
+	// Aqrab al-bilad. When the twilight angle is not reached, Fajr and Isha are
+	// calculated at latitude of 48.5 degree on the same longitude, the nearest
+	// latitude where the twilight of 18 degree is reached all year.
+	NEAREST_LATITUDE
+
+	// Aqrab al-ayyam. When the twilight angle is not reached, Fajr and Isha are
+	// taken from the last date on which the twilight angle was reached.
+	NEAREST_DAY
+
+	// Fajr and Isha are calculated from the twilight angle as long as it is reached.
+	// Otherwise Isha begins after the first one-seventh part of the night
+	// and Fajr is at the beginning of the seventh part.
+	ONE_SEVENTH_WHEN_UNREACHABLE
 )
 
+// Latitude that used by NEAREST_LATITUDE rule
+const NEAREST_LATITUDE_LIMIT = 48.5
+
+// Latitude above which the twilight angles may not be reached in summer,
+// Fajr and Isha are estimated from a portion of the night on those dates
+const HIGH_LATITUDE_LIMIT = 48.0
+
+// Maximum days before the date that searched by NEAREST_DAY rule
+const nearestDayLimit = 183
+
 func NewNightPortion(fajr float32, isha float32) *NightPortion {
 	return &NightPortion{
 		fajr: fajr,
 		Isha: isha,
 	}
 }
+
+// Time of the twilight angle on the date. Fajr is before transit and Isha after transit
+func twilightTime(solarTime *SolarTime, date *utils.DateComponents, angle float32, evening bool) (time.Time, error) {
+	hours, err := solarTime.HourAngle(-1*float64(angle), evening)
+	if err != nil {
+		return time.Time{}, ErrTwilightNotReached
+	}
+	return createDateComponents(hours, date)
+}
+
+func nearestLatitudeTwilight(solarTime *SolarTime, date *utils.DateComponents, angle float32, evening bool) (time.Time, error) {
+	coords := *solarTime.Obsever
+	coords.Latitude = math.Max(math.Min(coords.Latitude, NEAREST_LATITUDE_LIMIT), -NEAREST_LATITUDE_LIMIT)
+	return twilightTime(NewSolarTime(date, &coords, solarTime.Params), date, angle, evening)
+}
+
+func nearestDayTwilight(solarTime *SolarTime, date *utils.DateComponents, angle float32, evening bool) (time.Time, error) {
+	currentDate := date.ConvertToTime()
+	for i := 1; i <= nearestDayLimit; i++ {
+		day := utils.NewDateComponents(currentDate.AddDate(0, 0, -i))
+		hours, err := NewSolarTime(day, solarTime.Obsever, solarTime.Params).HourAngle(-1*float64(angle), evening)
+		if err == nil {
+			// Use the same time of the day on the date
+			return createDateComponents(hours, date)
+		}
+	}
+	return time.Time{}, ErrTwilightNotReached
+}
+
+// Compare the twilight with the safe value of the high latitude rule.
+// Fajr must not be earlier and Isha must not be later than the safe value.
+func clampTwilight(twilight time.Time, safe time.Time, evening bool, status PrayerStatus) (time.Time, PrayerStatus) {
+	if twilight.IsZero() || (!evening && twilight.Before(safe)) || (evening && twilight.After(safe)) {
+		return safe, ESTIMATED
+	}
+	return twilight, status
+}
+
+// Calculate Fajr (evening is false) or Isha (evening is true) from
+// the twilight angle and apply the high latitude rule of the parameter.
+//
+// 'sunTime' is the sunrise for Fajr or the sunset for Isha, and 'night'
+// is the length of the night. 'night' is zero when the sun doesn't set.
+func highLatitudeTwilight(
+	solarTime *SolarTime,
+	date *utils.DateComponents,
+	params *CalculationParameters,
+	angle float32,
+	evening bool,
+	sunTime time.Time,
+	night time.Duration,
+) (time.Time, PrayerStatus, error) {
+	twilight, err := twilightTime(solarTime, date, angle, evening)
+	if err != nil && !errors.Is(err, ErrTwilightNotReached) {
+		return time.Time{}, CALCULATED, err
+	}
+	status := CALCULATED
+	if twilight.IsZero() {
+		status = TWILIGHT_NOT_REACHED
+	}
+
+	direction := time.Duration(1)
+	if !evening {
+		direction = -1
+	}
+
+	if params.Method == MOONSIGHTING_COMMITTEE {
+		if night <= 0 {
+			return twilight, status, nil
+		}
+		// Above 55 degree north or south the committee uses one seventh of the night
+		if math.Abs(solarTime.Obsever.Latitude) >= 55 {
+			twilight = sunTime.Add(direction * night / 7)
+			status = ESTIMATED
+		}
+
+		currentDate := date.ConvertToTime()
+		safe := SeasonAdjustedMorningTwilight(
+			solarTime.Obsever.Latitude, currentDate.YearDay(), currentDate.Year(), sunTime,
+		)
+		if evening {
+			safe = SeasonAdjustedEveningTwilight(
+				solarTime.Obsever.Latitude, currentDate.YearDay(), currentDate.Year(), sunTime, params.Shafaq,
+			)
+		}
+		twilight, status = clampTwilight(twilight, safe, evening, status)
+		return twilight, status, nil
+	}
+
+	switch params.HighLatitudeRule {
+	case NONE:
+		return twilight, status, nil
+	case NEAREST_LATITUDE, NEAREST_DAY:
+		if !twilight.IsZero() {
+			return twilight, status, nil
+		}
+		if params.HighLatitudeRule == NEAREST_LATITUDE {
+			twilight, err = nearestLatitudeTwilight(solarTime, date, angle, evening)
+		} else {
+			twilight, err = nearestDayTwilight(solarTime, date, angle, evening)
+		}
+		if errors.Is(err, ErrTwilightNotReached) {
+			return twilight, TWILIGHT_NOT_REACHED, nil
+		} else if err != nil {
+			return time.Time{}, CALCULATED, err
+		}
+		return twilight, ESTIMATED, nil
+	}
+
+	if night <= 0 || (params.HighLatitudeRule == ONE_SEVENTH_WHEN_UNREACHABLE && !twilight.IsZero()) {
+		return twilight, status, nil
+	}
+	nightPortion, err := params.GetNightPortion()
+	if err != nil {
+		return time.Time{}, CALCULATED, err
+	}
+	portion := nightPortion.fajr
+	if evening {
+		portion = nightPortion.Isha
+	}
+	twilight, status = clampTwilight(twilight, sunTime.Add(direction*nightFraction(night, portion)), evening, status)
+	return twilight, status, nil
+}
+
+// Recommendation of high latitude rule for a location on a date
+type HighLatitudeRecommendation struct {
+	Rule   HighLatitudeRule
+	Reason string
+}
+
+// Recommend High Latitude Rule
+// returns the rule that fits the location on the date based on the latitude
+// and whether the twilight angles of the parameter are reached
+func RecommendHighLatitudeRule(coords *utils.Coordinates, date *utils.DateComponents, params *CalculationParameters) *HighLatitudeRecommendation {
+	params = params.ForDate(date)
+	if params.Method == MOONSIGHTING_COMMITTEE {
+		return &HighLatitudeRecommendation{
+			Rule:   NONE,
+			Reason: "Moonsighting Committee method uses its own season adjusted twilight",
+		}
+	}
+
+	solarTime := NewSolarTime(date, coords, params)
+	_, err := solarTime.HourAngle(-1*float64(params.FajrAngle), false)
+	twilightReached := err == nil
+	if params.IshaInterval <= 0 {
+		_, err = solarTime.HourAngle(-1*float64(params.IshaAngle), true)
+		twilightReached = twilightReached && err == nil
+	}
+
+	switch {
+	case twilightReached && math.Abs(coords.Latitude) <= HIGH_LATITUDE_LIMIT:
+		return &HighLatitudeRecommendation{
+			Rule:   NONE,
+			Reason: "twilight angles are reached on this date",
+		}
+	case twilightReached:
+		return &HighLatitudeRecommendation{
+			Rule: ONE_SEVENTH_WHEN_UNREACHABLE,
+			Reason: fmt.Sprintf(
+				"twilight angles are reached on this date, but latitude %.2f is beyond %v degree, Fajr and Isha are estimated from one seventh of the night on the dates they are not reached",
+				coords.Latitude, HIGH_LATITUDE_LIMIT,
+			),
+		}
+	case solarTime.Err == nil:
+		return &HighLatitudeRecommendation{
+			Rule: ONE_SEVENTH_WHEN_UNREACHABLE,
+			Reason: fmt.Sprintf(
+				"twilight angles are not reached on this date at latitude %.2f, unreachable Fajr and Isha are estimated from one seventh of the night",
+				coords.Latitude,
+			),
+		}
+	default:
+		return &HighLatitudeRecommendation{
+			Rule: NEAREST_LATITUDE,
+			Reason: fmt.Sprintf(
+				"%v and the night can't be divided, unreachable Fajr and Isha are calculated at latitude %v",
+				solarTime.Err, NEAREST_LATITUDE_LIMIT,
+			),
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package calc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+func TestRecommendHighLatitudeRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		date      time.Time
+		rule      HighLatitudeRule
+	}{
+		{"Jakarta", -6.2088, 106.8456, time.Date(2024, time.June, 20, 0, 0, 0, 0, time.UTC), NONE},
+		{"London in winter", 51.5074, -0.1278, time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC), ONE_SEVENTH_WHEN_UNREACHABLE},
+		{"London in summer", 51.5074, -0.1278, time.Date(2024, time.June, 20, 0, 0, 0, 0, time.UTC), ONE_SEVENTH_WHEN_UNREACHABLE},
+		{"Tromso in summer", 69.6496, 18.9560, time.Date(2024, time.June, 20, 0, 0, 0, 0, time.UTC), NEAREST_LATITUDE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coords, err := utils.NewCoordinates(tt.latitude, tt.longitude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			date := utils.NewDateComponents(tt.date)
+			params := GetCalculationMethod(MUSLIM_WORLD_LEAGUE)
+			recommendation := RecommendHighLatitudeRule(coords, date, params)
+			if recommendation.Rule != tt.rule {
+				t.Fatalf("rule = %v (%v), want %v", recommendation.Rule, recommendation.Reason, tt.rule)
+			}
+			if tt.rule != ONE_SEVENTH_WHEN_UNREACHABLE {
+				return
+			}
+
+			// Reached twilight is kept by the recommended rule
+			reached, err := NewPrayerTimes(coords, date, GetCalculationMethod(MUSLIM_WORLD_LEAGUE).SetHighLatitudeRule(NONE))
+			if err != nil {
+				t.Fatal(err)
+			}
+			recommended, err := NewPrayerTimes(coords, date, params.SetHighLatitudeRule(recommendation.Rule))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reached.Err(FAJR) == nil && !recommended.Fajr.Equal(reached.Fajr) {
+				t.Errorf("Fajr = %v, want %v", recommended.Fajr, reached.Fajr)
+			}
+			if reached.Err(ISHA) == nil && !recommended.Isha.Equal(reached.Isha) {
+				t.Errorf("Isha = %v, want %v", recommended.Isha, reached.Isha)
+			}
+		})
+	}
+}
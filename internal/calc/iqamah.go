@@ -0,0 +1,187 @@
+package calc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Iqamah
+
+Time of iqamah of each prayer that published by a mosque. It is
+derived from the adhan by the rules of the mosque, either minutes
+after the adhan or a fixed time, and may be rounded up.
+
+A rule can be limited to some months, to Friday or to Ramadan.
+When several rules of a prayer match the date, the most specific
+rule is used: Ramadan, then Friday, then months, then the general rule.
+*/
+type IqamahRule struct {
+	Prayer Prayer
+
+	// Minutes after the adhan
+	Offset int8
+
+	// Fixed time of iqamah in the time zone of the prayer times. It is used
+	// instead of Offset, unless the adhan is later than the fixed time
+	FixedTime *utils.TimeComponents
+
+	// Round up the iqamah to the next multiple of the minutes
+	RoundUp int8
+
+	// Months that use the rule. The rule is used in all months when it is empty
+	Months []time.Month
+
+	// The rule is used only on Friday
+	Friday bool
+
+	// The rule is used only in Ramadan
+	Ramadan bool
+}
+
+// Iqamah of the prayers on a date.
+// A prayer without a rule or without adhan is not listed
+type IqamahTimes struct {
+	Date  *utils.DateComponents
+	Times map[Prayer]time.Time
+}
+
+func (rule *IqamahRule) validate() error {
+	switch rule.Prayer {
+	case FAJR, DHUHR, ASR, MAGRIB, ISHA:
+	default:
+		return fmt.Errorf("invalid iqamah prayer")
+	}
+	if rule.Offset < 0 || rule.RoundUp < 0 {
+		return fmt.Errorf("iqamah offset and rounding must not be negative")
+	}
+	if rule.FixedTime != nil && (rule.FixedTime.Hours < 0 || rule.FixedTime.Hours > 23 ||
+		rule.FixedTime.Minutes < 0 || rule.FixedTime.Minutes > 59) {
+		return fmt.Errorf("invalid iqamah fixed time")
+	}
+	return nil
+}
+
+// Returns the priority of the rule on the date or -1 when it isn't used.
+// The date is the local date of the adhan
+func (rule *IqamahRule) priority(date time.Time, ramadan bool) int {
+	priority := 0
+	if rule.Ramadan {
+		if !ramadan {
+			return -1
+		}
+		priority += 4
+	}
+	if rule.Friday {
+		if date.Weekday() != time.Friday {
+			return -1
+		}
+		priority += 2
+	}
+	if len(rule.Months) > 0 {
+		found := false
+		for _, month := range rule.Months {
+			found = found || month == date.Month()
+		}
+		if !found {
+			return -1
+		}
+		priority += 1
+	}
+	return priority
+}
+
+// Round up the time to the next multiple of the minutes in its location
+func roundUpMinutes(t time.Time, minutes int8) time.Time {
+	if minutes <= 0 {
+		return t
+	}
+	step := time.Minute * time.Duration(minutes)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	elapsed := t.Sub(midnight)
+	return midnight.Add(((elapsed + step - 1) / step) * step)
+}
+
+func (rule *IqamahRule) iqamah(adhan time.Time) time.Time {
+	iqamah := adhan.Add(time.Minute * time.Duration(rule.Offset))
+	if rule.FixedTime != nil {
+		fixed := time.Date(
+			adhan.Year(), adhan.Month(), adhan.Day(),
+			int(rule.FixedTime.Hours), int(rule.FixedTime.Minutes), 0, 0, adhan.Location(),
+		)
+		if !fixed.Before(adhan) {
+			iqamah = fixed
+		}
+	}
+	return roundUpMinutes(iqamah, rule.RoundUp)
+}
+
+// Calculate the iqamah of the prayer times by the rules
+func NewIqamahTimes(prayerTimes *PrayerTimes, rules []IqamahRule) (*IqamahTimes, error) {
+	for i := range rules {
+		if err := rules[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	local := prayerTimes.Dhuhr
+	hijrDate := ConvertGeorgianToHijr(*utils.NewDateComponents(local))
+	ramadan := hijrDate.Month == 9
+
+	times := map[Prayer]time.Time{}
+	for _, prayer := range []Prayer{FAJR, DHUHR, ASR, MAGRIB, ISHA} {
+		adhan := prayerTimes.TimePray(prayer)
+		if adhan.IsZero() {
+			continue
+		}
+
+		var rule *IqamahRule
+		priority := -1
+		for i := range rules {
+			if rules[i].Prayer != prayer {
+				continue
+			}
+			if p := rules[i].priority(local, ramadan); p > priority {
+				rule, priority = &rules[i], p
+			}
+		}
+		if rule != nil {
+			times[prayer] = rule.iqamah(adhan)
+		}
+	}
+
+	return &IqamahTimes{
+		Date:  prayerTimes.DateComponent,
+		Times: times,
+	}, nil
+}
+
+// Calculate the iqamah of each date from 'startDate' until 'endDate'.
+// Prayer times are converted to the time zone before the rules are used
+func NewIqamahSchedule(
+	coords *utils.Coordinates,
+	startDate time.Time,
+	endDate time.Time,
+	params *CalculationParameters,
+	timezone string,
+	rules []IqamahRule,
+) ([]IqamahTimes, error) {
+	var schedule []IqamahTimes
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		prayerTimes, err := NewPrayerTimes(coords, utils.NewDateComponents(d), params)
+		if err != nil {
+			return nil, err
+		}
+		if err := prayerTimes.SetTimeZone(timezone); err != nil {
+			return nil, err
+		}
+		iqamahTimes, err := NewIqamahTimes(prayerTimes, rules)
+		if err != nil {
+			return nil, err
+		}
+		schedule = append(schedule, *iqamahTimes)
+	}
+	return schedule, nil
+}
@@ -0,0 +1,135 @@
+package calc
+
+import (
+	"sort"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Jama' and Qasr
+
+A traveller (musafir) may combine Dhuhr with Asr and Magrib with Isha.
+Jama' taqdim performs both prayers in the time of the first prayer and
+jama' ta'khir performs both prayers in the time of the second prayer.
+A traveller may also shorten (qasr) the four rakaat prayers into two.
+*/
+type JamaType int8
+
+const (
+	// Both prayers are performed in the time of the first prayer
+	JAMA_TAQDIM JamaType = iota
+
+	// Both prayers are performed in the time of the second prayer
+	JAMA_TAKHIR
+)
+
+// Time window when two prayers may be combined
+type JamaWindow struct {
+	Type   JamaType
+	First  Prayer
+	Second Prayer
+	Start  time.Time
+	End    time.Time
+
+	// Location of the traveller in the window
+	Coordinates *utils.Coordinates
+}
+
+// Location of a traveller from the given time
+// until the time of the next segment
+type TravelSegment struct {
+	Coordinates *utils.Coordinates
+	From        time.Time
+}
+
+// Returns the number of rakaat of the prayer, shortened when 'qasr' is true.
+// It is zero when the prayer isn't an obligatory prayer
+func Rakaat(prayer Prayer, qasr bool) int8 {
+	switch prayer {
+	case FAJR:
+		return 2
+	case MAGRIB:
+		return 3
+	case DHUHR, ASR, ISHA:
+		if qasr {
+			return 2
+		}
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Returns the jama' taqdim and jama' ta'khir windows of Dhuhr with Asr
+// and Magrib with Isha. A window is not returned when one of its prayers
+// doesn't exist on the date
+func (pray *PrayerTimes) JamaWindows() ([]JamaWindow, error) {
+	windows, err := pray.Windows()
+	if err != nil {
+		return nil, err
+	}
+	window := map[Prayer]PrayerWindow{}
+	for _, w := range windows {
+		window[w.Prayer] = w
+	}
+
+	var jamaWindows []JamaWindow
+	for _, pair := range [][2]Prayer{{DHUHR, ASR}, {MAGRIB, ISHA}} {
+		first, second := window[pair[0]], window[pair[1]]
+		if first.Start.IsZero() || second.Start.IsZero() {
+			continue
+		}
+		jamaWindows = append(jamaWindows,
+			JamaWindow{
+				Type: JAMA_TAQDIM, First: pair[0], Second: pair[1],
+				Start: first.Start, End: first.End, Coordinates: pray.Coordinates,
+			},
+			JamaWindow{
+				Type: JAMA_TAKHIR, First: pair[0], Second: pair[1],
+				Start: second.Start, End: second.End, Coordinates: pray.Coordinates,
+			},
+		)
+	}
+	return jamaWindows, nil
+}
+
+// Returns the jama' windows of a traveller whose location changes on the date.
+// The windows of each location are limited to the time that the traveller
+// spends there, so a window may be shortened or split between locations.
+func NewTravelJamaWindows(
+	segments []TravelSegment,
+	date *utils.DateComponents,
+	params *CalculationParameters,
+) ([]JamaWindow, error) {
+	segments = append([]TravelSegment{}, segments...)
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].From.Before(segments[j].From)
+	})
+
+	var jamaWindows []JamaWindow
+	for i, segment := range segments {
+		prayerTimes, err := NewPrayerTimes(segment.Coordinates, date, params)
+		if err != nil {
+			return nil, err
+		}
+		windows, err := prayerTimes.JamaWindows()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, window := range windows {
+			if window.Start.Before(segment.From) {
+				window.Start = segment.From
+			}
+			if i+1 < len(segments) && window.End.After(segments[i+1].From) {
+				window.End = segments[i+1].From
+			}
+			if window.Start.Before(window.End) {
+				jamaWindows = append(jamaWindows, window)
+			}
+		}
+	}
+	return jamaWindows, nil
+}
@@ -0,0 +1,84 @@
+package calc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Jumu'ah
+
+On Friday, Dhuhr is replaced by Jumu'ah prayer that preceded by khutbah.
+The khutbah starts at minutes after Dhuhr or at a fixed time, and large
+mosques may have several sessions.
+*/
+type JumuahRule struct {
+	// Minutes after Dhuhr when the khutbah starts
+	Offset int8
+
+	// Fixed time of the khutbah in the time zone of the prayer times.
+	// It is used instead of Offset, unless Dhuhr is later than the fixed time
+	FixedTime *utils.TimeComponents
+
+	// Minutes of the khutbah before the prayer
+	KhutbahDuration int8
+}
+
+// Session of Jumu'ah prayer
+type JumuahSession struct {
+	Khutbah time.Time
+	Prayer  time.Time
+}
+
+func (rule *JumuahRule) validate() error {
+	if rule.Offset < 0 || rule.KhutbahDuration < 0 {
+		return fmt.Errorf("jumuah offset and khutbah duration must not be negative")
+	}
+	if rule.FixedTime != nil && (rule.FixedTime.Hours < 0 || rule.FixedTime.Hours > 23 ||
+		rule.FixedTime.Minutes < 0 || rule.FixedTime.Minutes > 59) {
+		return fmt.Errorf("invalid jumuah fixed time")
+	}
+	return nil
+}
+
+func (rule *JumuahRule) session(dhuhr time.Time) JumuahSession {
+	khutbah := dhuhr.Add(time.Minute * time.Duration(rule.Offset))
+	if rule.FixedTime != nil {
+		fixed := time.Date(
+			dhuhr.Year(), dhuhr.Month(), dhuhr.Day(),
+			int(rule.FixedTime.Hours), int(rule.FixedTime.Minutes), 0, 0, dhuhr.Location(),
+		)
+		if !fixed.Before(dhuhr) {
+			khutbah = fixed
+		}
+	}
+	return JumuahSession{
+		Khutbah: khutbah,
+		Prayer:  khutbah.Add(time.Minute * time.Duration(rule.KhutbahDuration)),
+	}
+}
+
+// Returns true when the date of the prayer times is Friday
+func (pray *PrayerTimes) IsFriday() bool {
+	return pray.DateComponent.ConvertToTime().Weekday() == time.Friday
+}
+
+// Returns Jumu'ah sessions by the rules of the calculation parameters
+// in the time zone of the prayer times, ordered as the rules.
+// It is empty when the date isn't Friday
+func (pray *PrayerTimes) JumuahSessions() ([]JumuahSession, error) {
+	if !pray.IsFriday() || pray.Dhuhr.IsZero() {
+		return nil, nil
+	}
+	var sessions []JumuahSession
+	for i := range pray.CalculationParams.JumuahRules {
+		rule := &pray.CalculationParams.JumuahRules[i]
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, rule.session(pray.Dhuhr))
+	}
+	return sessions, nil
+}
@@ -2,7 +2,7 @@ package calc
 
 import "github.com/taufiq30s/adzan/internal/utils"
 
-type Mazhab float32
+type Mazhab int8
 
 const (
 	SYAFI Mazhab = iota + 1
@@ -6,11 +6,13 @@ package calc
 type CalculationMethod int
 
 const (
+	// Custom method that uses the default parameters
 	OTHER CalculationMethod = iota
+
 	// Muslim World League
 	// Used Fajr angle of 18 and an Isha angle of 17
 	// Main regions: Europe, Far East, parts of the USA
-	MUSLIM_WORLD_LEAGUE CalculationMethod = iota
+	MUSLIM_WORLD_LEAGUE
 
 	// Egyptian General Authority of Survey
 	// Used Fajr angle of 19.5 and an Isha angle of 17.5
@@ -53,55 +55,290 @@ const (
 
 	KEMENAG
 	MUHAMMADIYAH
+
+	// Moonsighting Committee
+	// Uses a Fajr angle of 18 and an Isha angle of 18. Fajr and Isha are
+	// clamped by season adjusted twilight based on the given shafaq
+	// Main Region: UK, North America
+	MOONSIGHTING_COMMITTEE
+
+	// Shia Ithna Ashari, Leva Institute, Qum
+	// Uses a Fajr angle of 16, an Isha angle of 14 and a Magrib angle of 4.
+	// The night is from sunset until Fajr
+	JAFARI
+
+	// Institute of Geophysics, University of Tehran
+	// Uses a Fajr angle of 17.7, an Isha angle of 14 and a Magrib angle of 4.5.
+	// The night is from sunset until Fajr
+	TEHRAN
+
+	// Jabatan Kemajuan Islam Malaysia
+	// Uses a Fajr angle of 20 and an Isha angle of 18
+	// Main Region: Malaysia
+	JAKIM
+
+	// Diyanet İşleri Başkanlığı
+	// Uses a Fajr angle of 18 and an Isha angle of 17
+	// Main Region: Turkey
+	DIYANET
+
+	// Islamic Affairs and Charitable Activities Department
+	// Uses a Fajr and an Isha angle of 18.2
+	// Main Region: United Arab Emirates
+	DUBAI
+
+	// Ministry of Habous and Islamic Affairs
+	// Uses a Fajr angle of 19 and an Isha angle of 17
+	// Main Region: Morocco
+	MOROCCO
+
+	// Ministry of Religious Affairs
+	// Uses a Fajr and an Isha angle of 18
+	// Main Region: Tunisia
+	TUNISIA
+
+	// Ministry of Religious Affairs and Wakfs
+	// Uses a Fajr angle of 18 and an Isha angle of 17
+	// Main Region: Algeria
+	ALGERIA
+
+	// Spiritual Administration of Muslims of Russia
+	// Uses a Fajr angle of 16 and an Isha angle of 15
+	// Main Region: Russia
+	RUSSIA
+
+	// Ministry of Awqaf, Islamic Affairs and Holy Places
+	// Uses a Fajr and an Isha angle of 18, Magrib is 5 minutes after sunset
+	// Main Region: Jordan
+	JORDAN
 )
 
+var builtinMethods = []MethodDefinition{
+	{
+		ID:              MUSLIM_WORLD_LEAGUE,
+		Key:             "muslimWorldLeague",
+		Name:            "Muslim World League",
+		Region:          "Europe, Far East, parts of the USA",
+		FajrAngle:       18.0,
+		IshaAngle:       17.0,
+		MethodAjustment: PrayerAjustment{Dhuhr: 1},
+	},
+	{
+		ID:              EGYPTIAN,
+		Key:             "egyptian",
+		Name:            "Egyptian General Authority of Survey",
+		Region:          "Africa, Syria, Iraq, Lebanon, Malaysia, parts of the USA",
+		FajrAngle:       19.5,
+		IshaAngle:       17.5,
+		MethodAjustment: PrayerAjustment{Dhuhr: 1},
+	},
+	{
+		ID:              NORTH_AMERICA,
+		Key:             "northAmerica",
+		Name:            "Islamic Society of North America",
+		Region:          "Parts of the USA, Canada, parts of the UK",
+		FajrAngle:       15.0,
+		IshaAngle:       15.0,
+		MethodAjustment: PrayerAjustment{Dhuhr: 1},
+	},
+	{
+		ID:        UOIF,
+		Key:       "uoif",
+		Name:      "Islamic Organisations Union of France",
+		Region:    "France",
+		FajrAngle: 12.0,
+		IshaAngle: 12.0,
+	},
+	{
+		ID:           UMM_AL_QURRA,
+		Key:          "ummAlQurra",
+		Name:         "Umm Al-Qurra University, Makkah",
+		Region:       "The Arabian Peninsula",
+		FajrAngle:    18.5,
+		IshaInterval: 90,
+		Rules: []MethodRule{
+			// Isha is 120 minutes after Magrib in Ramadan
+			{HijriMonths: []int8{9}, IshaInterval: 120},
+		},
+	},
+	{
+		ID:              KARACHI,
+		Key:             "karachi",
+		Name:            "University Of Islamic Sciences, Karachi",
+		Region:          "Pakistan, Bangladesh, India, Afghanistan, Parts of Europe",
+		FajrAngle:       18.0,
+		IshaAngle:       18.0,
+		MethodAjustment: PrayerAjustment{Dhuhr: 1},
+	},
+	{
+		ID:              SINGAPORE,
+		Key:             "singapore",
+		Name:            "Majlis Ugama Islam Singapura",
+		Region:          "Singapore, Malaysia, Brunei, Indonesia",
+		FajrAngle:       20.0,
+		IshaAngle:       18.0,
+		MethodAjustment: PrayerAjustment{Dhuhr: 1},
+	},
+	{
+		ID:        KUWAIT,
+		Key:       "kuwait",
+		Name:      "Kuwait",
+		Region:    "Kuwait",
+		FajrAngle: 18.0,
+		IshaAngle: 17.5,
+	},
+	{
+		ID:           QATAR,
+		Key:          "qatar",
+		Name:         "Qatar",
+		Region:       "Qatar",
+		FajrAngle:    18.0,
+		IshaInterval: 90,
+	},
+	{
+		ID:              KEMENAG,
+		Key:             "kemenag",
+		Name:            "Kementerian Agama Republik Indonesia",
+		Region:          "Indonesia",
+		FajrAngle:       20.0,
+		IshaAngle:       18.0,
+		MethodAjustment: PrayerAjustment{Fajr: 2, Dhuhr: 2, Asr: 2, Magrib: 2, Isha: 2},
+		Rounding: RoundingPolicy{
+			Imsak:   ROUND_DOWN,
+			Fajr:    ROUND_UP,
+			Sunrise: ROUND_DOWN,
+			Dhuhr:   ROUND_UP,
+			Asr:     ROUND_UP,
+			Magrib:  ROUND_UP,
+			Isha:    ROUND_UP,
+		},
+	},
+	{
+		ID:              MUHAMMADIYAH,
+		Key:             "muhammadiyah",
+		Name:            "Muhammadiyah",
+		Region:          "Indonesia",
+		FajrAngle:       18.0,
+		IshaAngle:       18.0,
+		MethodAjustment: PrayerAjustment{Fajr: 2, Dhuhr: 2, Asr: 2, Magrib: 2, Isha: 2},
+	},
+	{
+		ID:              MOONSIGHTING_COMMITTEE,
+		Key:             "moonsightingCommittee",
+		Name:            "Moonsighting Committee Worldwide",
+		Region:          "UK, North America",
+		FajrAngle:       18.0,
+		IshaAngle:       18.0,
+		Shafaq:          GENERAL,
+		MethodAjustment: PrayerAjustment{Dhuhr: 5, Magrib: 3},
+	},
+	{
+		ID:             JAFARI,
+		Key:            "jafari",
+		Name:           "Shia Ithna Ashari, Leva Institute, Qum",
+		Region:         "Shia communities",
+		FajrAngle:      16.0,
+		IshaAngle:      14.0,
+		MaghribAngle:   4.0,
+		MidnightMethod: SUNSET_TO_FAJR,
+	},
+	{
+		ID:             TEHRAN,
+		Key:            "tehran",
+		Name:           "Institute of Geophysics, University of Tehran",
+		Region:         "Iran",
+		FajrAngle:      17.7,
+		IshaAngle:      14.0,
+		MaghribAngle:   4.5,
+		MidnightMethod: SUNSET_TO_FAJR,
+	},
+	{
+		ID:              JAKIM,
+		Key:             "jakim",
+		Name:            "Jabatan Kemajuan Islam Malaysia",
+		Region:          "Malaysia",
+		FajrAngle:       20.0,
+		IshaAngle:       18.0,
+		MethodAjustment: PrayerAjustment{Dhuhr: 1},
+	},
+	{
+		ID:              DIYANET,
+		Key:             "diyanet",
+		Name:            "Diyanet İşleri Başkanlığı",
+		Region:          "Turkey",
+		FajrAngle:       18.0,
+		IshaAngle:       17.0,
+		ImsakAngle:      18.0, // İmsak is the beginning of Fajr
+		MethodAjustment: PrayerAjustment{Sunrise: -7, Dhuhr: 5, Asr: 4, Magrib: 7},
+	},
+	{
+		ID:              DUBAI,
+		Key:             "dubai",
+		Name:            "Islamic Affairs and Charitable Activities Department, Dubai",
+		Region:          "United Arab Emirates",
+		FajrAngle:       18.2,
+		IshaAngle:       18.2,
+		MethodAjustment: PrayerAjustment{Sunrise: -3, Dhuhr: 3, Asr: 3, Magrib: 3},
+	},
+	{
+		ID:              MOROCCO,
+		Key:             "morocco",
+		Name:            "Ministry of Habous and Islamic Affairs, Morocco",
+		Region:          "Morocco",
+		FajrAngle:       19.0,
+		IshaAngle:       17.0,
+		MethodAjustment: PrayerAjustment{Sunrise: -3, Dhuhr: 5, Magrib: 5},
+	},
+	{
+		ID:        TUNISIA,
+		Key:       "tunisia",
+		Name:      "Ministry of Religious Affairs, Tunisia",
+		Region:    "Tunisia",
+		FajrAngle: 18.0,
+		IshaAngle: 18.0,
+	},
+	{
+		ID:              ALGERIA,
+		Key:             "algeria",
+		Name:            "Ministry of Religious Affairs and Wakfs, Algeria",
+		Region:          "Algeria",
+		FajrAngle:       18.0,
+		IshaAngle:       17.0,
+		MethodAjustment: PrayerAjustment{Sunrise: -3, Dhuhr: 1, Magrib: 3},
+	},
+	{
+		ID:        RUSSIA,
+		Key:       "russia",
+		Name:      "Spiritual Administration of Muslims of Russia",
+		Region:    "Russia",
+		FajrAngle: 16.0,
+		IshaAngle: 15.0,
+	},
+	{
+		ID:              JORDAN,
+		Key:             "jordan",
+		Name:            "Ministry of Awqaf, Islamic Affairs and Holy Places, Jordan",
+		Region:          "Jordan",
+		FajrAngle:       18.0,
+		IshaAngle:       18.0,
+		MethodAjustment: PrayerAjustment{Magrib: 5},
+	},
+}
+
+func init() {
+	for _, definition := range builtinMethods {
+		if err := RegisterMethod(definition); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Returns the parameters of the method from the registry.
+// The default parameters are returned when the method isn't registered.
 func GetCalculationMethod(method CalculationMethod) *CalculationParameters {
-	param := NewCalculationParameter()
-	switch method {
-	case MUSLIM_WORLD_LEAGUE:
-		param.SetFajrAngle(18.0).SetIshaAngle(17.0).SetMethodAjustment(PrayerAjustment{
-			Dhuhr: 1,
-		})
-	case EGYPTIAN:
-		param.SetFajrAngle(19.5).SetIshaAngle(17.5).SetMethodAjustment(PrayerAjustment{
-			Dhuhr: 1,
-		})
-	case NORTH_AMERICA:
-		param.SetFajrAngle(15.0).SetIshaAngle(15.0).SetMethodAjustment(PrayerAjustment{
-			Dhuhr: 1,
-		})
-	case UOIF:
-		param.SetFajrAngle(12.0).SetIshaAngle(12.0)
-	case UMM_AL_QURRA:
-		param.SetFajrAngle(18.5).SetIshaInterval(90)
-	case KARACHI:
-		param.SetFajrAngle(18.0).SetIshaAngle(18.0).SetMethodAjustment(PrayerAjustment{
-			Dhuhr: 1,
-		})
-	case SINGAPORE:
-		param.SetFajrAngle(20.0).SetIshaAngle(18.0).SetMethodAjustment(PrayerAjustment{
-			Dhuhr: 1,
-		})
-	case KUWAIT:
-		param.SetFajrAngle(18.0).SetIshaAngle(17.5)
-	case QATAR:
-		param.SetFajrAngle(18.0).SetIshaInterval(90)
-	case KEMENAG:
-		param.SetFajrAngle(20.0).SetIshaAngle(18.0).SetMethodAjustment(PrayerAjustment{
-			Fajr:   2,
-			Dhuhr:  2,
-			Asr:    2,
-			Magrib: 2,
-			Isha:   2,
-		})
-	case MUHAMMADIYAH:
-		param.SetFajrAngle(18.0).SetIshaAngle(18.0).SetMethodAjustment(PrayerAjustment{
-			Fajr:   2,
-			Dhuhr:  2,
-			Asr:    2,
-			Magrib: 2,
-			Isha:   2,
-		})
+	definition, ok := GetMethodDefinition(method)
+	if !ok {
+		return NewCalculationParameter().SetMethod(method)
 	}
-	return param
+	return definition.Parameters()
 }
@@ -0,0 +1,146 @@
+package calc
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Definition of a calculation method in the registry.
+// Zero ImsakInterval, ImsakAngle, DhuhaAngle and Mazhab keep the default parameters.
+type MethodDefinition struct {
+	ID     CalculationMethod
+	Key    string
+	Name   string
+	Region string
+
+	FajrAngle      float32
+	IshaAngle      float32
+	MaghribAngle   float32
+	IshaInterval   int8
+	ImsakInterval  int8
+	ImsakAngle     float32
+	DhuhaAngle     float32
+	Mazhab         Mazhab
+	MidnightMethod MidnightMethod
+	Shafaq         Shafaq
+
+	MethodAjustment PrayerAjustment
+	Rounding        RoundingPolicy
+
+	// Parameters that are changed on some dates
+	Rules []MethodRule
+}
+
+var (
+	methodRegistry      = map[CalculationMethod]MethodDefinition{}
+	methodRegistryMutex sync.RWMutex
+)
+
+// Returns the calculation parameters of the method.
+// The rules are copied, so changing them doesn't change the registered method
+func (definition *MethodDefinition) Parameters() *CalculationParameters {
+	param := NewCalculationParameter().
+		SetMethod(definition.ID).
+		SetFajrAngle(definition.FajrAngle).
+		SetIshaAngle(definition.IshaAngle).
+		SetMaghribAngle(definition.MaghribAngle).
+		SetIshaInterval(definition.IshaInterval).
+		SetImsakAngle(definition.ImsakAngle).
+		SetMidnightMethod(definition.MidnightMethod).
+		SetShafaq(definition.Shafaq).
+		SetMethodAjustment(definition.MethodAjustment).
+		SetRounding(definition.Rounding).
+		SetRules(append([]MethodRule(nil), definition.Rules...)...)
+	if definition.ImsakInterval != 0 {
+		param.SetImsakInterval(definition.ImsakInterval)
+	}
+	if definition.DhuhaAngle != 0 {
+		param.SetDhuhaAngle(definition.DhuhaAngle)
+	}
+	if definition.Mazhab != 0 {
+		param.SetMazhab(definition.Mazhab)
+	}
+	return param
+}
+
+func (definition *MethodDefinition) validate() error {
+	if definition.ID == OTHER {
+		return fmt.Errorf("method id must not be OTHER")
+	}
+	if definition.Key == "" {
+		return fmt.Errorf("method key must not be empty")
+	}
+	if definition.FajrAngle <= 0 || definition.FajrAngle > 30 {
+		return fmt.Errorf("fajr angle of method %v must be between 0 and 30", definition.Key)
+	}
+	if definition.IshaAngle < 0 || definition.IshaAngle > 30 {
+		return fmt.Errorf("isha angle of method %v must be between 0 and 30", definition.Key)
+	}
+	if definition.IshaAngle == 0 && definition.IshaInterval <= 0 {
+		return fmt.Errorf("method %v must have isha angle or isha interval", definition.Key)
+	}
+	if definition.MaghribAngle < 0 || definition.MaghribAngle > 10 {
+		return fmt.Errorf("magrib angle of method %v must be between 0 and 10", definition.Key)
+	}
+	if definition.ImsakAngle < 0 || definition.ImsakAngle > 30 {
+		return fmt.Errorf("imsak angle of method %v must be between 0 and 30", definition.Key)
+	}
+	if definition.IshaInterval < 0 || definition.ImsakInterval < 0 || definition.DhuhaAngle < 0 {
+		return fmt.Errorf("intervals and dhuha angle of method %v must not be negative", definition.Key)
+	}
+	return nil
+}
+
+// Add the method to the registry or replace the method with the same ID.
+// The key must be unique among the methods
+func RegisterMethod(definition MethodDefinition) error {
+	if err := definition.validate(); err != nil {
+		return err
+	}
+
+	methodRegistryMutex.Lock()
+	defer methodRegistryMutex.Unlock()
+	for id, registered := range methodRegistry {
+		if id != definition.ID && registered.Key == definition.Key {
+			return fmt.Errorf("method key %v is already registered", definition.Key)
+		}
+	}
+	methodRegistry[definition.ID] = definition
+	return nil
+}
+
+func GetMethodDefinition(method CalculationMethod) (*MethodDefinition, bool) {
+	methodRegistryMutex.RLock()
+	defer methodRegistryMutex.RUnlock()
+	definition, ok := methodRegistry[method]
+	if !ok {
+		return nil, false
+	}
+	return &definition, true
+}
+
+func GetMethodDefinitionByKey(key string) (*MethodDefinition, bool) {
+	methodRegistryMutex.RLock()
+	defer methodRegistryMutex.RUnlock()
+	for _, definition := range methodRegistry {
+		if definition.Key == key {
+			return &definition, true
+		}
+	}
+	return nil, false
+}
+
+// Returns all registered methods ordered by ID
+func MethodDefinitions() []MethodDefinition {
+	methodRegistryMutex.RLock()
+	defer methodRegistryMutex.RUnlock()
+	definitions := make([]MethodDefinition, 0, len(methodRegistry))
+	for _, definition := range methodRegistry {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].ID < definitions[j].ID
+	})
+	return definitions
+}
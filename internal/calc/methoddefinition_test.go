@@ -0,0 +1,19 @@
+package calc
+
+import "testing"
+
+func TestParametersCopyRules(t *testing.T) {
+	definition, ok := GetMethodDefinition(UMM_AL_QURRA)
+	if !ok {
+		t.Fatal("Umm Al-Qurra is not registered")
+	}
+	want := definition.Rules[0].IshaInterval
+
+	params := definition.Parameters()
+	params.Rules[0].IshaInterval = want + 1
+	params.Rules = append(params.Rules, MethodRule{IshaInterval: 60})
+
+	if got := GetCalculationMethod(UMM_AL_QURRA).Rules; len(got) != len(definition.Rules) || got[0].IshaInterval != want {
+		t.Errorf("rules of the registered method = %v, want %v", got, definition.Rules)
+	}
+}
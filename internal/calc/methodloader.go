@@ -0,0 +1,286 @@
+package calc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Method Loader
+
+Calculation methods that declared in a JSON file, ex:
+
+	{
+		"methods": [
+			{
+				"key": "masjidAlIkhlas",
+				"name": "Masjid Al Ikhlas",
+				"region": "Medan",
+				"fajrAngle": 20,
+				"ishaAngle": 18,
+				"imsakInterval": 10,
+				"ajustment": {"fajr": 2, "dhuhr": 3, "asr": 2, "magrib": 2, "isha": 2},
+				"rounding": {"fajr": "up", "sunrise": "down"},
+				"rules": [
+					{"hijriMonths": [9], "imsakInterval": 15},
+					{"from": "11-01", "to": "02-28", "ishaAngle": 17}
+				]
+			}
+		]
+	}
+
+The methods get IDs from CUSTOM_METHOD_START and are selected by their key.
+Only JSON is supported, a YAML file has to be converted to JSON before it is loaded.
+*/
+type methodFile struct {
+	Methods []methodConfig `json:"methods"`
+}
+
+type methodConfig struct {
+	Key            string             `json:"key"`
+	Name           string             `json:"name"`
+	Region         string             `json:"region"`
+	FajrAngle      float32            `json:"fajrAngle"`
+	IshaAngle      float32            `json:"ishaAngle"`
+	MaghribAngle   float32            `json:"maghribAngle"`
+	IshaInterval   int8               `json:"ishaInterval"`
+	ImsakInterval  int8               `json:"imsakInterval"`
+	ImsakAngle     float32            `json:"imsakAngle"`
+	DhuhaAngle     float32            `json:"dhuhaAngle"`
+	Mazhab         string             `json:"mazhab"`
+	MidnightMethod string             `json:"midnightMethod"`
+	Shafaq         string             `json:"shafaq"`
+	Ajustment      PrayerAjustment    `json:"ajustment"`
+	Rounding       map[string]string  `json:"rounding"`
+	Rules          []methodRuleConfig `json:"rules"`
+}
+
+type methodRuleConfig struct {
+	HijriMonths   []int8           `json:"hijriMonths"`
+	From          string           `json:"from"`
+	To            string           `json:"to"`
+	FajrAngle     float32          `json:"fajrAngle"`
+	IshaAngle     float32          `json:"ishaAngle"`
+	MaghribAngle  float32          `json:"maghribAngle"`
+	IshaInterval  int8             `json:"ishaInterval"`
+	ImsakInterval int8             `json:"imsakInterval"`
+	Ajustment     *PrayerAjustment `json:"ajustment"`
+}
+
+// First ID of the methods that loaded from a file
+const CUSTOM_METHOD_START CalculationMethod = 100
+
+var midnightMethodByName = map[string]MidnightMethod{
+	"":                SUNSET_TO_SUNRISE,
+	"sunsetToSunrise": SUNSET_TO_SUNRISE,
+	"sunsetToFajr":    SUNSET_TO_FAJR,
+}
+
+var mazhabByName = map[string]Mazhab{
+	"":       SYAFI,
+	"syafi":  SYAFI,
+	"hanafi": HANAFI,
+}
+
+var shafaqByName = map[string]Shafaq{
+	"":        GENERAL,
+	"general": GENERAL,
+	"ahmar":   AHMAR,
+	"abyad":   ABYAD,
+}
+
+var roundingByName = map[string]Rounding{
+	"":        ROUND_NEAREST,
+	"nearest": ROUND_NEAREST,
+	"up":      ROUND_UP,
+	"down":    ROUND_DOWN,
+	"none":    ROUND_NONE,
+	"second":  ROUND_SECOND,
+}
+
+func (config *methodConfig) roundingPolicy() (RoundingPolicy, error) {
+	policy := RoundingPolicy{}
+	prayers := map[string]*Rounding{
+		"imsak":   &policy.Imsak,
+		"fajr":    &policy.Fajr,
+		"sunrise": &policy.Sunrise,
+		"dhuhr":   &policy.Dhuhr,
+		"asr":     &policy.Asr,
+		"magrib":  &policy.Magrib,
+		"isha":    &policy.Isha,
+	}
+	for prayer, name := range config.Rounding {
+		rounding, ok := roundingByName[name]
+		if !ok {
+			return policy, fmt.Errorf("invalid rounding %v of method %v", name, config.Key)
+		}
+		field, ok := prayers[prayer]
+		if !ok {
+			return policy, fmt.Errorf("invalid rounding prayer %v of method %v", prayer, config.Key)
+		}
+		*field = rounding
+	}
+	return policy, nil
+}
+
+// Parse month and day of a rule (ex: 11-01)
+func parseMonthDay(value string) (*utils.DateComponents, error) {
+	date, err := time.Parse("01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.DateComponents{Month: int8(date.Month()), Day: int8(date.Day())}, nil
+}
+
+func (config *methodRuleConfig) rule(key string) (*MethodRule, error) {
+	for _, month := range config.HijriMonths {
+		if month < 1 || month > 12 {
+			return nil, fmt.Errorf("invalid hijri month %v in rule of method %v", month, key)
+		}
+	}
+	rule := &MethodRule{
+		HijriMonths:     config.HijriMonths,
+		FajrAngle:       config.FajrAngle,
+		IshaAngle:       config.IshaAngle,
+		MaghribAngle:    config.MaghribAngle,
+		IshaInterval:    config.IshaInterval,
+		ImsakInterval:   config.ImsakInterval,
+		MethodAjustment: config.Ajustment,
+	}
+	if config.From != "" || config.To != "" {
+		from, err := parseMonthDay(config.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from in rule of method %v (ex: 11-01)", key)
+		}
+		to, err := parseMonthDay(config.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to in rule of method %v (ex: 02-28)", key)
+		}
+		rule.From, rule.To = from, to
+	}
+	return rule, nil
+}
+
+func (config *methodConfig) definition(id CalculationMethod) (*MethodDefinition, error) {
+	midnightMethod, ok := midnightMethodByName[config.MidnightMethod]
+	if !ok {
+		return nil, fmt.Errorf("invalid midnight method %v of method %v", config.MidnightMethod, config.Key)
+	}
+	shafaq, ok := shafaqByName[config.Shafaq]
+	if !ok {
+		return nil, fmt.Errorf("invalid shafaq %v of method %v", config.Shafaq, config.Key)
+	}
+	mazhab, ok := mazhabByName[config.Mazhab]
+	if !ok {
+		return nil, fmt.Errorf("invalid mazhab %v of method %v", config.Mazhab, config.Key)
+	}
+	rounding, err := config.roundingPolicy()
+	if err != nil {
+		return nil, err
+	}
+	var rules []MethodRule
+	for i := range config.Rules {
+		rule, err := config.Rules[i].rule(config.Key)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	definition := &MethodDefinition{
+		ID:              id,
+		Key:             config.Key,
+		Name:            config.Name,
+		Region:          config.Region,
+		FajrAngle:       config.FajrAngle,
+		IshaAngle:       config.IshaAngle,
+		MaghribAngle:    config.MaghribAngle,
+		IshaInterval:    config.IshaInterval,
+		ImsakInterval:   config.ImsakInterval,
+		ImsakAngle:      config.ImsakAngle,
+		DhuhaAngle:      config.DhuhaAngle,
+		Mazhab:          mazhab,
+		MidnightMethod:  midnightMethod,
+		Shafaq:          shafaq,
+		MethodAjustment: config.Ajustment,
+		Rounding:        rounding,
+		Rules:           rules,
+	}
+	if err := definition.validate(); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// Returns the next ID that is not used by a registered method
+func nextCustomMethodID() CalculationMethod {
+	id := CUSTOM_METHOD_START
+	for _, definition := range MethodDefinitions() {
+		if definition.ID >= id {
+			id = definition.ID + 1
+		}
+	}
+	return id
+}
+
+// Load methods from JSON and register them.
+// Nothing is registered when a method is invalid
+func LoadMethods(r io.Reader) ([]MethodDefinition, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var file methodFile
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid methods file: %v", err)
+	}
+
+	keys := map[string]bool{}
+	id := nextCustomMethodID()
+	var definitions []MethodDefinition
+	for i := range file.Methods {
+		config := &file.Methods[i]
+		if keys[config.Key] {
+			return nil, fmt.Errorf("method key %v is declared more than once", config.Key)
+		}
+		if _, ok := GetMethodDefinitionByKey(config.Key); ok {
+			return nil, fmt.Errorf("method key %v is already registered", config.Key)
+		}
+		keys[config.Key] = true
+
+		definition, err := config.definition(id + CalculationMethod(i))
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, *definition)
+	}
+
+	for _, definition := range definitions {
+		if err := RegisterMethod(definition); err != nil {
+			return nil, err
+		}
+	}
+	return definitions, nil
+}
+
+func LoadMethodsFile(path string) ([]MethodDefinition, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadMethods(file)
+}
+
+// Returns the parameters of the registered method with the key
+func GetCalculationMethodByKey(key string) (*CalculationParameters, error) {
+	definition, ok := GetMethodDefinitionByKey(key)
+	if !ok {
+		return nil, fmt.Errorf("method %v is not registered", key)
+	}
+	return definition.Parameters(), nil
+}
@@ -0,0 +1,92 @@
+package calc
+
+import (
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+// Parameters of a method that are changed on some dates, ex: Umm Al-Qurra
+// uses Isha interval of 120 minutes in Ramadan instead of 90 minutes.
+//
+// A rule is used when the date is in its Hijri months and in its Gregorian
+// range. Zero values and nil ajustment keep the parameters of the method.
+type MethodRule struct {
+	// Hijri months of the rule, all months when it is empty
+	HijriMonths []int8
+
+	// Yearly range of Gregorian date, inclusive. The year is ignored
+	// and the range may pass the end of the year (ex: November until February).
+	// All dates are in the range when they are nil
+	From *utils.DateComponents
+	To   *utils.DateComponents
+
+	FajrAngle       float32
+	IshaAngle       float32
+	MaghribAngle    float32
+	IshaInterval    int8
+	ImsakInterval   int8
+	MethodAjustment *PrayerAjustment
+}
+
+func monthDay(date *utils.DateComponents) int {
+	return int(date.Month)*100 + int(date.Day)
+}
+
+func (rule *MethodRule) matches(date *utils.DateComponents, hijrMonth int8) bool {
+	if len(rule.HijriMonths) > 0 {
+		found := false
+		for _, month := range rule.HijriMonths {
+			found = found || month == hijrMonth
+		}
+		if !found {
+			return false
+		}
+	}
+	if rule.From != nil && rule.To != nil {
+		day, from, to := monthDay(date), monthDay(rule.From), monthDay(rule.To)
+		if from <= to {
+			return from <= day && day <= to
+		}
+		return day >= from || day <= to
+	}
+	return true
+}
+
+func (rule *MethodRule) apply(param *CalculationParameters) {
+	if rule.FajrAngle != 0 {
+		param.SetFajrAngle(rule.FajrAngle)
+	}
+	if rule.IshaAngle != 0 {
+		param.SetIshaAngle(rule.IshaAngle)
+	}
+	if rule.MaghribAngle != 0 {
+		param.SetMaghribAngle(rule.MaghribAngle)
+	}
+	if rule.IshaInterval != 0 {
+		param.SetIshaInterval(rule.IshaInterval)
+	}
+	if rule.ImsakInterval != 0 {
+		param.SetImsakInterval(rule.ImsakInterval)
+	}
+	if rule.MethodAjustment != nil {
+		param.SetMethodAjustment(*rule.MethodAjustment)
+	}
+}
+
+// Returns a copy of the parameters with the rules of the date.
+// The copy has no rules because they are applied, so it is used
+// as is for any date and the changes on it are kept.
+func (param *CalculationParameters) ForDate(date *utils.DateComponents) *CalculationParameters {
+	if len(param.Rules) == 0 {
+		return param
+	}
+
+	dated := *param
+	dated.Rules = nil
+	hijrMonth := ConvertGeorgianToHijr(*date).Month
+	for i := range param.Rules {
+		if param.Rules[i].matches(date, hijrMonth) {
+			param.Rules[i].apply(&dated)
+		}
+	}
+	return &dated
+}
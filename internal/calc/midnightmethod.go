@@ -0,0 +1,19 @@
+package calc
+
+/*
+Midnight Method
+
+Definition of the night that used to calculate the Islamic midnight
+and the last third of the night. The night always starts at sunset.
+*/
+type MidnightMethod int8
+
+const (
+	// The night ends at tomorrow sunrise.
+	// This is the default value that used by the Sunni schools
+	SUNSET_TO_SUNRISE MidnightMethod = iota
+
+	// The night ends at tomorrow Fajr.
+	// Used by Jafari method
+	SUNSET_TO_FAJR
+)
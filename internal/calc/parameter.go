@@ -1,6 +1,10 @@
 package calc
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
 
 type CalculationParameters struct {
 	// Method that will be used
@@ -12,31 +16,108 @@ type CalculationParameters struct {
 	// The angle of sun to calculate Isha
 	IshaAngle float32
 
+	// The angle of sun to calculate Magrib. Magrib is at sunset when it is zero
+	MaghribAngle float32
+
 	// Minutes after magrib (Time of Isha = Magrib + IshaInterval)
 	IshaInterval int8
 
+	// Minutes before Fajr (Time of Imsak = Fajr - ImsakInterval).
+	// The default is 10 minutes as published by Kemenag and JAKIM
+	ImsakInterval int8
+
+	// The angle of sun to calculate Imsak. It is used instead of ImsakInterval
+	// when it is set, ImsakInterval is used when the angle isn't reached
+	ImsakAngle float32
+
+	// The altitude of sun when Dhuha starts.
+	// The default is 4.5 degree as published by Kemenag
+	DhuhaAngle float32
+
+	// The altitude of sun when it has risen a spear's length.
+	// It ends the forbidden window after sunrise and starts the one before sunset
+	SpearLengthAngle float32
+
+	// Minutes of the forbidden window before zawal
+	ZawalInterval int8
+
+	// Sessions of Jumu'ah prayer on Friday
+	JumuahRules []JumuahRule
+
 	// The Juristic method to calculate ashr
 	Mazhab Mazhab
 
+	// Shadow length of Asr as a factor of the length of an object.
+	// It is used instead of Mazhab when it is positive
+	ShadowFactor float64
+
+	// Calculate Asr of both Syafi'i and Hanafi
+	DualAsr bool
+
+	// Portion of the night when the preferred time of Isha ends.
+	// The default is 1/2, Isha is preferred until midnight
+	IshaPreferredPortion float32
+
 	HighLatitudeRule HighLatitudeRule
 
+	// Definition of the night for midnight and the last third of the night
+	MidnightMethod MidnightMethod
+
+	// The twilight to calculate Isha in Moonsighting Committee method
+	Shafaq Shafaq
+
+	// Model of atmospheric refraction
+	Refraction RefractionModel
+
+	// Air pressure in millibars for refraction model
+	Pressure float64
+
+	// Air temperature in celsius for refraction model
+	Temperature float64
+
+	// Provider of the sun position
+	SolarPosition SolarPositionProvider
+
+	// Rounding of each prayer time
+	Rounding RoundingPolicy
+
 	// Manual Ajustment
 	Ajustment PrayerAjustment
 
 	// Ajustment that set by a calculation method
 	MethodAjustment PrayerAjustment
+
+	// Parameters that are changed on some dates
+	Rules []MethodRule
 }
 
 func NewCalculationParameter() *CalculationParameters {
 	return &CalculationParameters{
-		Method:           OTHER,
-		FajrAngle:        0.0,
-		IshaAngle:        0.0,
-		IshaInterval:     0,
-		Mazhab:           SYAFI,
-		HighLatitudeRule: MIDDLE_OF_THE_NIGHT,
-		Ajustment:        PrayerAjustment{},
-		MethodAjustment:  PrayerAjustment{},
+		Method:               OTHER,
+		FajrAngle:            0.0,
+		IshaAngle:            0.0,
+		MaghribAngle:         0.0,
+		IshaInterval:         0,
+		ImsakInterval:        10,
+		ImsakAngle:           0.0,
+		DhuhaAngle:           4.5,
+		SpearLengthAngle:     3.0,
+		ZawalInterval:        5,
+		JumuahRules:          []JumuahRule{{Offset: 0, KhutbahDuration: 15}},
+		Mazhab:               SYAFI,
+		ShadowFactor:         0,
+		DualAsr:              false,
+		IshaPreferredPortion: 1.0 / 2.0,
+		HighLatitudeRule:     MIDDLE_OF_THE_NIGHT,
+		MidnightMethod:       SUNSET_TO_SUNRISE,
+		Shafaq:               GENERAL,
+		Refraction:           STANDARD_REFRACTION,
+		Pressure:             STANDARD_PRESSURE,
+		Temperature:          STANDARD_TEMPERATURE,
+		SolarPosition:        MeeusSolarPosition{},
+		Rounding:             RoundingPolicy{},
+		Ajustment:            PrayerAjustment{},
+		MethodAjustment:      PrayerAjustment{},
 	}
 }
 
@@ -55,16 +136,79 @@ func (param *CalculationParameters) SetIshaAngle(angle float32) *CalculationPara
 	return param
 }
 
+func (param *CalculationParameters) SetMaghribAngle(angle float32) *CalculationParameters {
+	param.MaghribAngle = angle
+	return param
+}
+
 func (param *CalculationParameters) SetIshaInterval(interval int8) *CalculationParameters {
 	param.IshaInterval = interval
 	return param
 }
 
+func (param *CalculationParameters) SetImsakInterval(interval int8) *CalculationParameters {
+	param.ImsakInterval = interval
+	return param
+}
+
+func (param *CalculationParameters) SetImsakAngle(angle float32) *CalculationParameters {
+	param.ImsakAngle = angle
+	return param
+}
+
+func (param *CalculationParameters) SetDhuhaAngle(angle float32) *CalculationParameters {
+	param.DhuhaAngle = angle
+	return param
+}
+
+func (param *CalculationParameters) SetSpearLengthAngle(angle float32) *CalculationParameters {
+	param.SpearLengthAngle = angle
+	return param
+}
+
+func (param *CalculationParameters) SetZawalInterval(interval int8) *CalculationParameters {
+	param.ZawalInterval = interval
+	return param
+}
+
+func (param *CalculationParameters) SetJumuahRules(rules ...JumuahRule) *CalculationParameters {
+	param.JumuahRules = rules
+	return param
+}
+
 func (param *CalculationParameters) SetMazhab(mazhab Mazhab) *CalculationParameters {
 	param.Mazhab = mazhab
 	return param
 }
 
+func (param *CalculationParameters) SetRounding(rounding RoundingPolicy) *CalculationParameters {
+	param.Rounding = rounding
+	return param
+}
+
+func (param *CalculationParameters) SetShadowFactor(factor float64) *CalculationParameters {
+	param.ShadowFactor = factor
+	return param
+}
+
+func (param *CalculationParameters) SetDualAsr(dualAsr bool) *CalculationParameters {
+	param.DualAsr = dualAsr
+	return param
+}
+
+func (param *CalculationParameters) SetIshaPreferredPortion(portion float32) *CalculationParameters {
+	param.IshaPreferredPortion = portion
+	return param
+}
+
+// Returns the shadow factor of Asr from ShadowFactor or Mazhab
+func (param *CalculationParameters) AsrShadowFactor() float64 {
+	if param.ShadowFactor > 0 {
+		return param.ShadowFactor
+	}
+	return utils.ShadowLengthToFloatMap[MazhabToShadowLengthMap[param.Mazhab]]
+}
+
 func (param *CalculationParameters) SetAjustment(ajustment PrayerAjustment) *CalculationParameters {
 	param.Ajustment = ajustment
 	return param
@@ -75,16 +219,47 @@ func (param *CalculationParameters) SetHighLatitudeRule(highLatitudeRule HighLat
 	return param
 }
 
+func (param *CalculationParameters) SetMidnightMethod(midnightMethod MidnightMethod) *CalculationParameters {
+	param.MidnightMethod = midnightMethod
+	return param
+}
+
+func (param *CalculationParameters) SetShafaq(shafaq Shafaq) *CalculationParameters {
+	param.Shafaq = shafaq
+	return param
+}
+
+func (param *CalculationParameters) SetRefraction(refraction RefractionModel) *CalculationParameters {
+	param.Refraction = refraction
+	return param
+}
+
+func (param *CalculationParameters) SetAtmosphere(pressure float64, temperature float64) *CalculationParameters {
+	param.Pressure = pressure
+	param.Temperature = temperature
+	return param
+}
+
+func (param *CalculationParameters) SetSolarPosition(provider SolarPositionProvider) *CalculationParameters {
+	param.SolarPosition = provider
+	return param
+}
+
 func (param *CalculationParameters) SetMethodAjustment(ajusment PrayerAjustment) *CalculationParameters {
 	param.MethodAjustment = ajusment
 	return param
 }
 
+func (param *CalculationParameters) SetRules(rules ...MethodRule) *CalculationParameters {
+	param.Rules = rules
+	return param
+}
+
 func (param *CalculationParameters) GetNightPortion() (*NightPortion, error) {
 	switch param.HighLatitudeRule {
 	case MIDDLE_OF_THE_NIGHT:
 		return NewNightPortion(1.0/2.0, 1.0/2.0), nil
-	case ONE_SEVENTH_OF_THE_NIGHT:
+	case ONE_SEVENTH_OF_THE_NIGHT, ONE_SEVENTH_WHEN_UNREACHABLE:
 		return NewNightPortion(1.0/7.0, 1.0/7.0), nil
 	case ANGLE_BASED_METHOD:
 		return NewNightPortion(param.FajrAngle/60.0, param.IshaAngle/60.0), nil
@@ -18,4 +18,11 @@ const (
 	MAGRIB
 
 	ISHA
+
+	MIDNIGHT
+
+	LAST_THIRD
+
+	// Replaces Dhuhr on Friday
+	JUMUAH
 )
@@ -0,0 +1,169 @@
+package calc
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Prayer Schedule
+
+Stream of prayer events of a location across days, months and years.
+The prayer times of each date are calculated when they are needed,
+so the schedule can be iterated without an end by Next.
+*/
+type PrayerSchedule struct {
+	Coordinates       *utils.Coordinates
+	CalculationParams *CalculationParameters
+	Location          *time.Location
+
+	// Prayers that are yielded by the schedule, set it by SetPrayers.
+	// Dhuhr is yielded as Jumu'ah at the time of Dhuhr on Friday
+	Prayers []Prayer
+
+	mutex sync.Mutex
+
+	// Events of the calculated dates, ordered by time and prayer
+	events map[utils.DateComponents][]PrayerEvent
+}
+
+// Time of a prayer on the date of its prayer times
+type PrayerEvent struct {
+	Prayer Prayer
+	Time   time.Time
+	Date   utils.DateComponents
+}
+
+// Maximum days that searched by Next
+const prayerScheduleLimit = 366
+
+func NewPrayerSchedule(coords *utils.Coordinates, params *CalculationParameters, loc *time.Location) *PrayerSchedule {
+	if params == nil {
+		params = NewCalculationParameter()
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &PrayerSchedule{
+		Coordinates:       coords,
+		CalculationParams: params,
+		Location:          loc,
+		Prayers:           []Prayer{IMSAK, FAJR, SUNRISE, DHUHR, ASR, MAGRIB, ISHA},
+		events:            map[utils.DateComponents][]PrayerEvent{},
+	}
+}
+
+func (schedule *PrayerSchedule) SetPrayers(prayers ...Prayer) *PrayerSchedule {
+	schedule.mutex.Lock()
+	defer schedule.mutex.Unlock()
+	schedule.Prayers = prayers
+	schedule.events = map[utils.DateComponents][]PrayerEvent{}
+	return schedule
+}
+
+// Events of the date in the time zone of the schedule
+func (schedule *PrayerSchedule) dateEvents(date utils.DateComponents) ([]PrayerEvent, error) {
+	if events, ok := schedule.events[date]; ok {
+		return events, nil
+	}
+	prayerTimes, err := NewPrayerTimes(schedule.Coordinates, &date, schedule.CalculationParams)
+	if err != nil {
+		return nil, err
+	}
+	prayerTimes.SetLocation(schedule.Location)
+
+	var events []PrayerEvent
+	for _, prayer := range schedule.Prayers {
+		t := prayerTimes.TimePray(prayer)
+		if t.IsZero() {
+			continue
+		}
+		// Jumu'ah is yielded at Dhuhr, the sessions are in JumuahSessions
+		if prayer == DHUHR && prayerTimes.IsFriday() {
+			prayer = JUMUAH
+		}
+		events = append(events, PrayerEvent{Prayer: prayer, Time: t, Date: date})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].before(&events[j])
+	})
+	schedule.events[date] = events
+	return events, nil
+}
+
+// Events are ordered by time, then by the prayer when they are at the same time,
+// ex: Imsak and Fajr of Diyanet
+func (event *PrayerEvent) before(other *PrayerEvent) bool {
+	if !event.Time.Equal(other.Time) {
+		return event.Time.Before(other.Time)
+	}
+	return event.Prayer < other.Prayer
+}
+
+// Returns the first prayer event after 't'
+func (schedule *PrayerSchedule) Next(t time.Time) (*PrayerEvent, error) {
+	return schedule.next(t, func(event *PrayerEvent) bool {
+		return event.Time.After(t)
+	})
+}
+
+// Returns the first prayer event after 'event', it is used to step
+// through the schedule without skipping the events at the same time
+func (schedule *PrayerSchedule) NextAfter(event *PrayerEvent) (*PrayerEvent, error) {
+	return schedule.next(event.Time, func(other *PrayerEvent) bool {
+		return event.before(other)
+	})
+}
+
+// Returns the first event that is 'after' from the date of 't'.
+// Events of the previous date are included because Isha, midnight
+// and the last third of the night may be after midnight, they may
+// even be after Imsak and Fajr of the next date at high latitudes.
+// The search ends when the first event of the next date isn't before
+// the found event because the events of later dates are after it
+func (schedule *PrayerSchedule) next(t time.Time, after func(event *PrayerEvent) bool) (*PrayerEvent, error) {
+	schedule.mutex.Lock()
+	defer schedule.mutex.Unlock()
+
+	local := t.In(schedule.Location)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	// Forget the dates that are passed
+	for date := range schedule.events {
+		if date.ConvertToTime().Before(start) {
+			delete(schedule.events, date)
+		}
+	}
+
+	var found *PrayerEvent
+	for i := 0; i <= prayerScheduleLimit; i++ {
+		events, err := schedule.dateEvents(*utils.NewDateComponents(start.AddDate(0, 0, i)))
+		if err != nil {
+			return nil, err
+		}
+		for j := range events {
+			if after(&events[j]) && (found == nil || events[j].before(found)) {
+				event := events[j]
+				found = &event
+			}
+		}
+		if found == nil {
+			continue
+		}
+
+		nextEvents, err := schedule.dateEvents(*utils.NewDateComponents(start.AddDate(0, 0, i+1)))
+		if err != nil {
+			return nil, err
+		}
+		if len(nextEvents) > 0 && !nextEvents[0].before(found) {
+			return found, nil
+		}
+	}
+	if found != nil {
+		return found, nil
+	}
+	return nil, ErrNoPrayerEvent
+}
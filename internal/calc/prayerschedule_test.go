@@ -0,0 +1,136 @@
+package calc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+func TestPrayerScheduleNext(t *testing.T) {
+	coords, err := utils.NewCoordinates(-6.2088, 106.8456)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := GetCalculationMethod(KEMENAG)
+	wib := time.FixedZone("WIB", 7*60*60)
+
+	// Thursday, the next day is Friday
+	thursday := utils.NewDateComponents(time.Date(2024, time.May, 23, 0, 0, 0, 0, time.UTC))
+	friday := utils.NewDateComponents(time.Date(2024, time.May, 24, 0, 0, 0, 0, time.UTC))
+	thursdayTimes, err := NewPrayerTimes(coords, thursday, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fridayTimes, err := NewPrayerTimes(coords, friday, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schedule := NewPrayerSchedule(coords, params, wib)
+	want := []struct {
+		prayer Prayer
+		time   time.Time
+	}{
+		{IMSAK, fridayTimes.Imsak},
+		{FAJR, fridayTimes.Fajr},
+		{SUNRISE, fridayTimes.Sunrise},
+		{JUMUAH, fridayTimes.Dhuhr},
+		{ASR, fridayTimes.Ashr},
+	}
+
+	// Rolls over from Isha of Thursday to Friday
+	current := thursdayTimes.Isha
+	for _, tt := range want {
+		event, err := schedule.Next(current)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event.Prayer != tt.prayer || !event.Time.Equal(tt.time) || event.Date != *friday {
+			t.Fatalf("Next(%v) = %v at %v on %v, want %v at %v on %v",
+				current, event.Prayer, event.Time, event.Date, tt.prayer, tt.time, *friday)
+		}
+		if event.Time.Location() != wib {
+			t.Errorf("location of %v = %v, want %v", event.Prayer, event.Time.Location(), wib)
+		}
+		current = event.Time
+	}
+}
+
+// The last third of the night is after Imsak and Fajr of the next date
+// in the summer of London, Isha and midnight are at the same time
+func TestPrayerScheduleHighLatitude(t *testing.T) {
+	coords, err := utils.NewCoordinates(51.5074, -0.1278)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bst := time.FixedZone("BST", 60*60)
+	prayers := []Prayer{IMSAK, FAJR, SUNRISE, DHUHR, ASR, MAGRIB, ISHA, MIDNIGHT, LAST_THIRD}
+	schedule := NewPrayerSchedule(coords, GetCalculationMethod(MUSLIM_WORLD_LEAGUE), bst).SetPrayers(prayers...)
+
+	// Thursday and Friday
+	dates := []utils.DateComponents{
+		*utils.NewDateComponents(time.Date(2024, time.June, 20, 0, 0, 0, 0, time.UTC)),
+		*utils.NewDateComponents(time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC)),
+	}
+	seen := map[utils.DateComponents]map[Prayer]int{}
+
+	event, err := schedule.Next(time.Date(2024, time.June, 20, 0, 0, 0, 0, bst))
+	if err != nil {
+		t.Fatal(err)
+	}
+	end := time.Date(2024, time.June, 22, 12, 0, 0, 0, bst)
+	for event.Time.Before(end) {
+		if seen[event.Date] == nil {
+			seen[event.Date] = map[Prayer]int{}
+		}
+		seen[event.Date][event.Prayer]++
+
+		next, err := schedule.NextAfter(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.Time.Before(event.Time) {
+			t.Fatalf("NextAfter(%v at %v) = %v at %v", event.Prayer, event.Time, next.Prayer, next.Time)
+		}
+		event = next
+	}
+
+	for _, date := range dates {
+		for _, prayer := range prayers {
+			if prayer == DHUHR && date.ConvertToTime().Weekday() == time.Friday {
+				prayer = JUMUAH
+			}
+			if seen[date][prayer] != 1 {
+				t.Errorf("%v on %v is yielded %v times, want 1", prayer, date, seen[date][prayer])
+			}
+		}
+	}
+}
+
+// İmsak of Diyanet is at the time of Fajr, both are yielded
+func TestPrayerScheduleSameTime(t *testing.T) {
+	coords, err := utils.NewCoordinates(41.0082, 28.9784)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule := NewPrayerSchedule(coords, GetCalculationMethod(DIYANET), time.FixedZone("TRT", 3*60*60))
+
+	event, err := schedule.Next(time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []Prayer
+	for len(got) < 5 {
+		got = append(got, event.Prayer)
+		if event, err = schedule.NextAfter(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []Prayer{ASR, MAGRIB, ISHA, IMSAK, FAJR}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("prayers = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package calc
+
+import "errors"
+
+// State of a prayer time in PrayerTimes
+type PrayerStatus int8
+
+const (
+	// Calculated from the position of the sun
+	CALCULATED PrayerStatus = iota
+
+	// Estimated because the angle of the time is not reached or later
+	// than the safe value, e.g. by the high latitude rule, the season of
+	// Moonsighting Committee or the interval of Imsak
+	ESTIMATED
+
+	// The time doesn't exist because the sun never rises
+	SUN_NEVER_RISES
+
+	// The time doesn't exist because the sun never sets
+	SUN_NEVER_SETS
+
+	// The time doesn't exist because the twilight angle is not reached
+	TWILIGHT_NOT_REACHED
+)
+
+func newPrayerStatus(err error) PrayerStatus {
+	switch {
+	case errors.Is(err, ErrSunNeverRises):
+		return SUN_NEVER_RISES
+	case errors.Is(err, ErrSunNeverSets):
+		return SUN_NEVER_SETS
+	case errors.Is(err, ErrTwilightNotReached):
+		return TWILIGHT_NOT_REACHED
+	default:
+		return CALCULATED
+	}
+}
+
+// Returns the sentinel error of the status
+// or nil when the time exists
+func (status PrayerStatus) Err() error {
+	switch status {
+	case SUN_NEVER_RISES:
+		return ErrSunNeverRises
+	case SUN_NEVER_SETS:
+		return ErrSunNeverSets
+	case TWILIGHT_NOT_REACHED:
+		return ErrTwilightNotReached
+	default:
+		return nil
+	}
+}
@@ -1,6 +1,7 @@
 package calc
 
 import (
+	"errors"
 	"time"
 
 	"github.com/taufiq30s/adzan/internal/utils"
@@ -12,11 +13,26 @@ type PrayerTimes struct {
 	Sunrise           time.Time
 	Dhuhr             time.Time
 	Ashr              time.Time
+	AshrSyafi         time.Time // Only when DualAsr is set
+	AshrHanafi        time.Time // Only when DualAsr is set
 	Magrib            time.Time
 	Isha              time.Time
+	Midnight          time.Time
+	LastThird         time.Time
 	Coordinates       *utils.Coordinates
 	DateComponent     *utils.DateComponents
 	CalculationParams *CalculationParameters
+
+	// State of each prayer time. A prayer that is not listed is CALCULATED.
+	// The time of a prayer that doesn't exist on the date is zero.
+	Status map[Prayer]PrayerStatus
+
+	// Sunset and the length of the night of MidnightMethod without ajustment
+	sunset      time.Time
+	nightLength time.Duration
+
+	// Parameters before the rules of the date are applied, for the next date
+	params *CalculationParameters
 }
 
 func createDateComponents(d float64, date *utils.DateComponents) (time.Time, error) {
@@ -27,137 +43,294 @@ func createDateComponents(d float64, date *utils.DateComponents) (time.Time, err
 	return timeComponents.DateComponents(date), nil
 }
 
+// Returns the given portion of the night rounded down to seconds
+func nightFraction(night time.Duration, portion float32) time.Duration {
+	return time.Second * time.Duration(int64(portion*float32(night.Seconds())))
+}
+
+// Add the ajustment in minutes and round the result.
+// Zero time is kept because the prayer doesn't exist.
+func adjustTime(t time.Time, ajustment int8, rounding Rounding) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return rounding.Round(t.Add(time.Minute * time.Duration(ajustment)))
+}
+
+// The night is from sunset until tomorrow sunrise.
+// It is zero when the sun doesn't set today or doesn't rise tomorrow.
+func nightDuration(
+	solarTime *SolarTime,
+	tommorowSolarTime *SolarTime,
+	date *utils.DateComponents,
+	tommorowDate *utils.DateComponents,
+) (time.Duration, error) {
+	if solarTime.Err != nil || tommorowSolarTime.Err != nil {
+		return 0, nil
+	}
+	sunset, err := createDateComponents(solarTime.Sunset, date)
+	if err != nil {
+		return 0, err
+	}
+	tommorowSunrise, err := createDateComponents(tommorowSolarTime.Sunrise, tommorowDate)
+	if err != nil {
+		return 0, err
+	}
+	return tommorowSunrise.Sub(sunset), nil
+}
+
+// Fajr of the given date without ajustment.
+// It is used as the end of the night by SUNSET_TO_FAJR
+func unadjustedFajr(coords *utils.Coordinates, date *utils.DateComponents, params *CalculationParameters) (time.Time, PrayerStatus, error) {
+	params = params.ForDate(date)
+	tommorowDate := utils.NewDateComponents(date.ConvertToTime().AddDate(0, 0, 1))
+	solarTime := NewSolarTime(date, coords, params)
+
+	night, err := nightDuration(solarTime, NewSolarTime(tommorowDate, coords, params), date, tommorowDate)
+	if err != nil {
+		return time.Time{}, CALCULATED, err
+	}
+	var sunrise time.Time
+	if solarTime.Err == nil {
+		sunrise, err = createDateComponents(solarTime.Sunrise, date)
+		if err != nil {
+			return time.Time{}, CALCULATED, err
+		}
+	}
+	return highLatitudeTwilight(solarTime, date, params, params.FajrAngle, false, sunrise, night)
+}
+
+// Time of Asr by the shadow factor.
+// The status is not CALCULATED when Asr doesn't exist on the date
+func afternoonTime(solarTime *SolarTime, date *utils.DateComponents, factor float64) (time.Time, PrayerStatus, error) {
+	afternoon, err := solarTime.AfternoonByShadowFactor(factor)
+	if err != nil {
+		return time.Time{}, newPrayerStatus(err), nil
+	}
+	t, err := createDateComponents(afternoon, date)
+	return t, CALCULATED, err
+}
+
 func NewPrayerTimes(coords *utils.Coordinates, date *utils.DateComponents, params *CalculationParameters) (*PrayerTimes, error) {
+	if params == nil {
+		params = NewCalculationParameter()
+	}
+	undatedParams := params
+	params = params.ForDate(date)
 	currentDate := date.ConvertToTime()
 
 	tommorowDate := utils.NewDateComponents(currentDate.AddDate(0, 0, 1))
 
-	solarTime := NewSolarTime(date, coords)
+	solarTime := NewSolarTime(date, coords, params)
+	status := map[Prayer]PrayerStatus{}
 
 	tempDhuhr, err := createDateComponents(solarTime.Transit, date)
 	if err != nil {
 		return nil, err
 	}
 
-	tempSunrise, err := createDateComponents(solarTime.Sunrise, date)
-	if err != nil {
-		return nil, err
+	var tempSunrise, tempSunset time.Time
+	if solarTime.Err != nil {
+		status[SUNRISE] = newPrayerStatus(solarTime.Err)
+		status[MAGRIB] = newPrayerStatus(solarTime.Err)
+	} else {
+		tempSunrise, err = createDateComponents(solarTime.Sunrise, date)
+		if err != nil {
+			return nil, err
+		}
+
+		tempSunset, err = createDateComponents(solarTime.Sunset, date)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	tempMaghrib, err := createDateComponents(solarTime.Sunset, date)
-	if err != nil {
-		return nil, err
+	// Magrib is at sunset unless it is calculated from an angle.
+	// When the angle isn't reached, sunset is used
+	tempMaghrib := tempSunset
+	if params.MaghribAngle > 0 && !tempSunset.IsZero() {
+		twilight, err := twilightTime(solarTime, date, params.MaghribAngle, true)
+		if err != nil && !errors.Is(err, ErrTwilightNotReached) {
+			return nil, err
+		}
+		if twilight.IsZero() {
+			status[MAGRIB] = ESTIMATED
+		} else {
+			tempMaghrib = twilight
+		}
 	}
 
-	tommorowSolarTime := NewSolarTime(tommorowDate, coords)
-	tommorowSunriseComponents, err := utils.NewTimeComponents(tommorowSolarTime.Sunrise)
+	tempAshr, ashrStatus, err := afternoonTime(solarTime, date, params.AsrShadowFactor())
 	if err != nil {
 		return nil, err
 	}
+	if ashrStatus != CALCULATED {
+		status[ASR] = ashrStatus
+	}
 
-	tempAshr, err := createDateComponents(solarTime.Afternoon(MazhabToShadowLengthMap[params.Mazhab]), date)
-	if err != nil {
-		return nil, err
+	var tempAshrSyafi, tempAshrHanafi time.Time
+	if params.DualAsr {
+		tempAshrSyafi, _, err = afternoonTime(solarTime, date, utils.ShadowLengthToFloatMap[utils.SINGLE])
+		if err != nil {
+			return nil, err
+		}
+		tempAshrHanafi, _, err = afternoonTime(solarTime, date, utils.ShadowLengthToFloatMap[utils.DOUBLE])
+		if err != nil {
+			return nil, err
+		}
 	}
-	tommorowSunrise := tommorowSunriseComponents.DateComponents(tommorowDate)
-	night := tommorowSunrise.Sub(tempMaghrib) * 1000
 
-	// Fajr Calculation
-	tempFajr, err := createDateComponents(solarTime.HourAngle(-1*float64(params.FajrAngle), false), date)
+	tommorowSolarTime := NewSolarTime(tommorowDate, coords, params)
+	night, err := nightDuration(solarTime, tommorowSolarTime, date, tommorowDate)
 	if err != nil {
 		return nil, err
 	}
-	nightPortion, err := params.GetNightPortion()
+
+	// Fajr Calculation with check againts safe value
+	tempFajr, fajrStatus, err := highLatitudeTwilight(
+		solarTime, date, params, params.FajrAngle, false, tempSunrise, night,
+	)
 	if err != nil {
 		return nil, err
 	}
-	portion := nightPortion.fajr
-	nightFraction := (int64)(portion * float32(night.Seconds()) / 1000)
-	safeFajr := tempSunrise.Add(time.Second * time.Duration(-1*(int)(nightFraction)))
-
-	if tempFajr.IsZero() || tempFajr.Before(safeFajr) {
-		tempFajr = safeFajr
+	if fajrStatus != CALCULATED {
+		status[FAJR] = fajrStatus
 	}
 
 	// Isha Calculation with check againts safe value
 	var tempIsha time.Time
 	if params.IshaInterval > 0 {
-		tempIsha = tempMaghrib.Add(time.Second * time.Duration(params.IshaInterval*60))
+		if tempMaghrib.IsZero() {
+			status[ISHA] = status[MAGRIB]
+		} else {
+			tempIsha = tempMaghrib.Add(time.Minute * time.Duration(params.IshaInterval))
+		}
 	} else {
-		tempIsha, err = createDateComponents(solarTime.HourAngle(-1*float64(params.IshaAngle), true), date)
+		var ishaStatus PrayerStatus
+		tempIsha, ishaStatus, err = highLatitudeTwilight(
+			solarTime, date, params, params.IshaAngle, true, tempSunset, night,
+		)
 		if err != nil {
 			return nil, err
 		}
+		if ishaStatus != CALCULATED {
+			status[ISHA] = ishaStatus
+		}
+	}
 
-		portion = nightPortion.Isha
-		nightFraction = int64(portion * float32(night.Seconds()) / 1000)
-		safeIsha := tempMaghrib.Add(time.Second * time.Duration(nightFraction))
-
-		if tempIsha.IsZero() || tempIsha.After(safeIsha) {
-			tempIsha = safeIsha
+	// Midnight and the last third of the night. When tomorrow Fajr
+	// doesn't exist, the night of SUNSET_TO_FAJR ends at sunrise
+	var midnight, lastThird time.Time
+	var nightLength time.Duration
+	if night > 0 {
+		nightEnd := tempSunset.Add(night)
+		if params.MidnightMethod == SUNSET_TO_FAJR {
+			tommorowFajr, tommorowFajrStatus, err := unadjustedFajr(coords, tommorowDate, undatedParams)
+			if err != nil {
+				return nil, err
+			}
+			if !tommorowFajr.IsZero() {
+				nightEnd = tommorowFajr
+			}
+			if tommorowFajrStatus != CALCULATED {
+				status[MIDNIGHT] = ESTIMATED
+				status[LAST_THIRD] = ESTIMATED
+			}
+		}
+		nightLength = nightEnd.Sub(tempSunset)
+		midnight = adjustTime(tempSunset.Add(nightFraction(nightLength, 1.0/2.0)), 0, ROUND_NEAREST)
+		lastThird = adjustTime(tempSunset.Add(nightFraction(nightLength, 2.0/3.0)), 0, ROUND_NEAREST)
+	} else {
+		nightStatus := newPrayerStatus(solarTime.Err)
+		if solarTime.Err == nil {
+			nightStatus = newPrayerStatus(tommorowSolarTime.Err)
 		}
+		status[MIDNIGHT] = nightStatus
+		status[LAST_THIRD] = nightStatus
 	}
 
 	// Assign final times to public struct members with all offsets
-	fajr := utils.RoundToNearestMinutes(
-		tempFajr.Add(
-			time.Minute * time.Duration(params.Ajustment.Fajr+params.MethodAjustment.Fajr),
-		),
-	)
-	sunrise := utils.RoundToNearestMinutes(
-		tempSunrise.Add(
-			time.Minute * time.Duration(params.Ajustment.Sunrise+params.MethodAjustment.Sunrise),
-		),
-	)
-	dhuhr := utils.RoundToNearestMinutes(
-		tempDhuhr.Add(
-			time.Minute * time.Duration(params.Ajustment.Dhuhr+params.MethodAjustment.Dhuhr),
-		),
-	)
-	ashr := utils.RoundToNearestMinutes(
-		tempAshr.Add(
-			time.Minute * time.Duration(params.Ajustment.Asr+params.MethodAjustment.Asr),
-		),
-	)
-	maghrib := utils.RoundToNearestMinutes(
-		tempMaghrib.Add(
-			time.Minute * time.Duration(params.Ajustment.Magrib+params.MethodAjustment.Magrib),
-		),
-	)
-	isha := utils.RoundToNearestMinutes(
-		tempIsha.Add(
-			time.Minute * time.Duration(params.Ajustment.Isha+params.MethodAjustment.Isha),
-		),
-	)
+	fajr := adjustTime(tempFajr, params.Ajustment.Fajr+params.MethodAjustment.Fajr, params.Rounding.Fajr)
+	sunrise := adjustTime(tempSunrise, params.Ajustment.Sunrise+params.MethodAjustment.Sunrise, params.Rounding.Sunrise)
+	dhuhr := adjustTime(tempDhuhr, params.Ajustment.Dhuhr+params.MethodAjustment.Dhuhr, params.Rounding.Dhuhr)
+	ashr := adjustTime(tempAshr, params.Ajustment.Asr+params.MethodAjustment.Asr, params.Rounding.Asr)
+	ashrSyafi := adjustTime(tempAshrSyafi, params.Ajustment.Asr+params.MethodAjustment.Asr, params.Rounding.Asr)
+	ashrHanafi := adjustTime(tempAshrHanafi, params.Ajustment.Asr+params.MethodAjustment.Asr, params.Rounding.Asr)
+	maghrib := adjustTime(tempMaghrib, params.Ajustment.Magrib+params.MethodAjustment.Magrib, params.Rounding.Magrib)
+	isha := adjustTime(tempIsha, params.Ajustment.Isha+params.MethodAjustment.Isha, params.Rounding.Isha)
+
+	// Imsak by angle is only used when Fajr is calculated from its angle,
+	// otherwise it is estimated from the interval before Fajr.
+	// It is compared with Fajr before the ajustment and the rounding,
+	// so Imsak at the angle of Fajr is kept on every date
+	var imsak time.Time
+	if params.ImsakAngle > 0 && fajrStatus == CALCULATED {
+		tempImsak, err := twilightTime(solarTime, date, params.ImsakAngle, false)
+		if err != nil && !errors.Is(err, ErrTwilightNotReached) {
+			return nil, err
+		}
+		if !tempImsak.IsZero() && !tempImsak.After(tempFajr) {
+			imsak = adjustTime(tempImsak, 0, params.Rounding.Imsak)
+		}
+	}
+	if imsak.IsZero() && !fajr.IsZero() {
+		imsak = params.Rounding.Imsak.Round(fajr.Add(-time.Minute * time.Duration(params.ImsakInterval)))
+		if params.ImsakAngle > 0 {
+			status[IMSAK] = ESTIMATED
+		}
+	}
+	if s, ok := status[FAJR]; ok {
+		status[IMSAK] = s
+	}
 
 	return &PrayerTimes{
-		Imsak:             fajr.Add(-time.Minute * 10),
+		Imsak:             imsak,
 		Fajr:              fajr,
 		Sunrise:           sunrise,
 		Dhuhr:             dhuhr,
 		Ashr:              ashr,
+		AshrSyafi:         ashrSyafi,
+		AshrHanafi:        ashrHanafi,
 		Magrib:            maghrib,
 		Isha:              isha,
+		Midnight:          midnight,
+		LastThird:         lastThird,
 		Coordinates:       coords,
 		DateComponent:     date,
 		CalculationParams: params,
+		Status:            status,
+		sunset:            tempSunset,
+		nightLength:       nightLength,
+		params:            undatedParams,
 	}, nil
 }
 
+// Returns nil when the time of the prayer exists on the date,
+// otherwise ErrSunNeverRises, ErrSunNeverSets or ErrTwilightNotReached
+func (pray *PrayerTimes) Err(prayer Prayer) error {
+	return pray.Status[prayer].Err()
+}
+
 func (prayer *PrayerTimes) CurrentPrayer() Prayer {
 	currentTime := time.Now().Unix()
+	passed := func(t time.Time) bool {
+		return !t.IsZero() && t.Unix()-currentTime <= 0
+	}
 	switch {
-	case prayer.Isha.Unix()-currentTime <= 0:
+	case passed(prayer.Isha):
 		return ISHA
-	case prayer.Magrib.Unix()-currentTime <= 0:
+	case passed(prayer.Magrib):
 		return MAGRIB
-	case prayer.Ashr.Unix()-currentTime <= 0:
+	case passed(prayer.Ashr):
 		return ASR
-	case prayer.Dhuhr.Unix()-currentTime <= 0:
+	case passed(prayer.Dhuhr):
+		if prayer.IsFriday() {
+			return JUMUAH
+		}
 		return DHUHR
-	case prayer.Fajr.Unix()-currentTime <= 0:
+	case passed(prayer.Fajr):
 		return FAJR
-	case prayer.Imsak.Unix()-currentTime <= 0:
+	case passed(prayer.Imsak):
 		return IMSAK
 	default:
 		return NO_PRAYER
@@ -165,8 +338,29 @@ func (prayer *PrayerTimes) CurrentPrayer() Prayer {
 }
 
 func (prayer *PrayerTimes) NextPrayer() Prayer {
-	currentPrayer := prayer.CurrentPrayer()
-	return (currentPrayer + 1) % 7
+	dhuhr := DHUHR
+	if prayer.IsFriday() {
+		dhuhr = JUMUAH
+	}
+	switch prayer.CurrentPrayer() {
+	case NO_PRAYER:
+		return IMSAK
+	case IMSAK:
+		return FAJR
+	case FAJR:
+		if !prayer.Sunrise.IsZero() && time.Now().Before(prayer.Sunrise) {
+			return SUNRISE
+		}
+		return dhuhr
+	case DHUHR, JUMUAH:
+		return ASR
+	case ASR:
+		return MAGRIB
+	case MAGRIB:
+		return ISHA
+	default:
+		return IMSAK
+	}
 }
 
 func (pray *PrayerTimes) TimePray(prayer Prayer) time.Time {
@@ -185,6 +379,16 @@ func (pray *PrayerTimes) TimePray(prayer Prayer) time.Time {
 		return pray.Magrib
 	case ISHA:
 		return pray.Isha
+	case MIDNIGHT:
+		return pray.Midnight
+	case LAST_THIRD:
+		return pray.LastThird
+	case JUMUAH:
+		// Time of Jumu'ah starts at Dhuhr, see JumuahSessions for the khutbah and the prayer
+		if !pray.IsFriday() {
+			return time.Time{}
+		}
+		return pray.Dhuhr
 	default:
 		return time.Time{}
 	}
@@ -195,13 +399,22 @@ func (pray *PrayerTimes) SetTimeZone(tz string) error {
 	if err != nil {
 		return err
 	}
+	pray.SetLocation(loc)
+	return nil
+}
+
+// Convert the prayer times to the location,
+// it can be a location that isn't loaded by name (ex: time.FixedZone)
+func (pray *PrayerTimes) SetLocation(loc *time.Location) {
 	pray.Imsak = pray.Imsak.In(loc)
 	pray.Fajr = pray.Fajr.In(loc)
 	pray.Sunrise = pray.Sunrise.In(loc)
 	pray.Dhuhr = pray.Dhuhr.In(loc)
 	pray.Ashr = pray.Ashr.In(loc)
+	pray.AshrSyafi = pray.AshrSyafi.In(loc)
+	pray.AshrHanafi = pray.AshrHanafi.In(loc)
 	pray.Magrib = pray.Magrib.In(loc)
 	pray.Isha = pray.Isha.In(loc)
-
-	return nil
+	pray.Midnight = pray.Midnight.In(loc)
+	pray.LastThird = pray.LastThird.In(loc)
 }
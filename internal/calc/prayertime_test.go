@@ -0,0 +1,32 @@
+package calc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+func TestDiyanetImsak(t *testing.T) {
+	coords, err := utils.NewCoordinates(41.0082, 28.9784)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := GetCalculationMethod(DIYANET)
+
+	// İmsak is at the beginning of Fajr on every date
+	start := time.Date(2024, time.February, 25, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 14; i++ {
+		date := utils.NewDateComponents(start.AddDate(0, 0, i))
+		prayerTimes, err := NewPrayerTimes(coords, date, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !prayerTimes.Imsak.Equal(prayerTimes.Fajr) {
+			t.Errorf("%v: Imsak = %v, want %v", *date, prayerTimes.Imsak, prayerTimes.Fajr)
+		}
+		if status := prayerTimes.Status[IMSAK]; status != CALCULATED {
+			t.Errorf("%v: status of Imsak = %v, want %v", *date, status, CALCULATED)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package calc
+
+import (
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+// Time window of an obligatory prayer.
+// The prayer may be performed until End, but it is preferred
+// to be performed before PreferredEnd.
+//
+// Fields are zero when the time doesn't exist on the date
+type PrayerWindow struct {
+	Prayer       Prayer
+	Start        time.Time
+	End          time.Time
+	PreferredEnd time.Time
+}
+
+// Returns true when 't' is in [Start, End)
+func (window *PrayerWindow) Contains(t time.Time) bool {
+	if window.Start.IsZero() || window.End.IsZero() {
+		return false
+	}
+	return !t.Before(window.Start) && t.Before(window.End)
+}
+
+// Returns the duration from 't' until the end of the window
+// or zero when the window has ended
+func (window *PrayerWindow) Remaining(t time.Time) time.Duration {
+	if window.End.IsZero() || !t.Before(window.End) {
+		return 0
+	}
+	return window.End.Sub(t)
+}
+
+// Returns the duration from 't' until the end of the preferred time
+// or zero when the preferred time has ended
+func (window *PrayerWindow) PreferredRemaining(t time.Time) time.Duration {
+	if window.PreferredEnd.IsZero() || !t.Before(window.PreferredEnd) {
+		return 0
+	}
+	return window.PreferredEnd.Sub(t)
+}
+
+// Fajr of tomorrow with all offsets of tomorrow in the location of the prayer times
+func (pray *PrayerTimes) tommorowFajr() (time.Time, error) {
+	tommorowDate := utils.NewDateComponents(pray.DateComponent.ConvertToTime().AddDate(0, 0, 1))
+	params := pray.CalculationParams
+	if pray.params != nil {
+		params = pray.params.ForDate(tommorowDate)
+	}
+	fajr, _, err := unadjustedFajr(pray.Coordinates, tommorowDate, params)
+	if err != nil {
+		return time.Time{}, err
+	}
+	fajr = adjustTime(fajr, params.Ajustment.Fajr+params.MethodAjustment.Fajr, params.Rounding.Fajr)
+	return fajr.In(pray.Dhuhr.Location()), nil
+}
+
+// Returns the window of Fajr, Dhuhr, Asr, Magrib and Isha.
+//
+// Fajr ends at sunrise, Dhuhr at Asr, Asr at Magrib and Magrib at Isha.
+// The preferred time of Asr ends when the sun becomes yellow. Isha ends at
+// tomorrow Fajr and its preferred time ends at midnight, or at the
+// IshaPreferredPortion of the night when it is set.
+func (pray *PrayerTimes) Windows() ([]PrayerWindow, error) {
+	sunnahTimes, err := NewSunnahTimes(pray)
+	if err != nil {
+		return nil, err
+	}
+	tommorowFajr, err := pray.tommorowFajr()
+	if err != nil {
+		return nil, err
+	}
+
+	asrPreferredEnd := sunnahTimes.ForbiddenAtSunset.Start
+	if asrPreferredEnd.IsZero() || asrPreferredEnd.Before(pray.Ashr) {
+		asrPreferredEnd = pray.Magrib
+	}
+
+	// The night of the preferred time uses the same definition as midnight
+	ishaPreferredEnd := pray.Midnight
+	portion := pray.CalculationParams.IshaPreferredPortion
+	if portion > 0 && portion != 1.0/2.0 && pray.nightLength > 0 {
+		ishaPreferredEnd = adjustTime(pray.sunset.Add(nightFraction(pray.nightLength, portion)), 0, ROUND_NEAREST)
+		ishaPreferredEnd = ishaPreferredEnd.In(pray.Dhuhr.Location())
+	}
+	if ishaPreferredEnd.IsZero() {
+		ishaPreferredEnd = tommorowFajr
+	}
+
+	return []PrayerWindow{
+		{Prayer: FAJR, Start: pray.Fajr, End: pray.Sunrise, PreferredEnd: pray.Sunrise},
+		{Prayer: DHUHR, Start: pray.Dhuhr, End: pray.Ashr, PreferredEnd: pray.Ashr},
+		{Prayer: ASR, Start: pray.Ashr, End: pray.Magrib, PreferredEnd: asrPreferredEnd},
+		{Prayer: MAGRIB, Start: pray.Magrib, End: pray.Isha, PreferredEnd: pray.Isha},
+		{Prayer: ISHA, Start: pray.Isha, End: tommorowFajr, PreferredEnd: ishaPreferredEnd},
+	}, nil
+}
+
+// Returns the window of the given prayer or nil when it isn't an obligatory prayer
+func (pray *PrayerTimes) Window(prayer Prayer) (*PrayerWindow, error) {
+	windows, err := pray.Windows()
+	if err != nil {
+		return nil, err
+	}
+	for i := range windows {
+		if windows[i].Prayer == prayer {
+			return &windows[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Returns the window that contains 't' or nil when there is no
+// obligatory prayer at 't', ex: between sunrise and Dhuhr
+func (pray *PrayerTimes) WindowAt(t time.Time) (*PrayerWindow, error) {
+	windows, err := pray.Windows()
+	if err != nil {
+		return nil, err
+	}
+	for i := range windows {
+		if windows[i].Contains(t) {
+			return &windows[i], nil
+		}
+	}
+	return nil, nil
+}
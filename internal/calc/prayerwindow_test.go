@@ -0,0 +1,47 @@
+package calc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+func TestIshaPreferredEnd(t *testing.T) {
+	coords, err := utils.NewCoordinates(-6.2088, 106.8456)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := utils.NewDateComponents(time.Date(2024, time.May, 23, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		params  *CalculationParameters
+		portion float64
+	}{
+		{"Syafi'i", GetCalculationMethod(KEMENAG).SetMazhab(SYAFI), 1.0 / 2.0},
+		{"Hanafi", GetCalculationMethod(KEMENAG).SetMazhab(HANAFI), 1.0 / 2.0},
+		{"One third", GetCalculationMethod(KEMENAG).SetIshaPreferredPortion(1.0 / 3.0), 1.0 / 3.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prayerTimes, err := NewPrayerTimes(coords, date, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			windows, err := prayerTimes.Windows()
+			if err != nil {
+				t.Fatal(err)
+			}
+			isha := windows[len(windows)-1]
+			want := prayerTimes.sunset.Add(time.Duration(float64(prayerTimes.nightLength) * tt.portion))
+			if diff := isha.PreferredEnd.Sub(want); diff < -time.Minute || diff > time.Minute {
+				t.Errorf("preferred end of Isha = %v, want %v", isha.PreferredEnd, want)
+			}
+			if tt.portion == 1.0/2.0 && !isha.PreferredEnd.Equal(prayerTimes.Midnight) {
+				t.Errorf("preferred end of Isha = %v, want midnight %v", isha.PreferredEnd, prayerTimes.Midnight)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package calc
+
+import (
+	"math"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Qibla
+
+Direction of the Kaaba from a location. The qibla is the initial bearing
+of the great circle to the Kaaba. The rhumb line keeps a constant bearing
+along the way and is given for comparison.
+
+Reference: https://www.movable-type.co.uk/scripts/latlong.html
+*/
+type QiblaDirection struct {
+	// Initial bearing of the great circle in degrees, measured clockwise from the north
+	Bearing float64
+
+	// Constant bearing of the rhumb line in degrees, measured clockwise from the north
+	RhumbBearing float64
+
+	// Great circle distance to the Kaaba in kilometers
+	Distance float64
+}
+
+// Coordinates of the Kaaba
+var Kaaba = utils.Coordinates{Latitude: 21.4225241, Longitude: 39.8261818}
+
+// Mean radius of the earth in kilometers
+const earthRadius = 6371.0088
+
+// Qibla direction and distance from 'coords'.
+// The bearing is zero at the Kaaba
+func Qibla(coords *utils.Coordinates) *QiblaDirection {
+	phi1 := utils.Radians(coords.Latitude)
+	phi2 := utils.Radians(Kaaba.Latitude)
+	dLambda := utils.Radians(utils.ClosestAngle(Kaaba.Longitude - coords.Longitude))
+
+	bearing := utils.Degrees(math.Atan2(
+		math.Sin(dLambda)*math.Cos(phi2),
+		(math.Cos(phi1)*math.Sin(phi2))-(math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)),
+	))
+
+	// Haversine formula
+	a := math.Pow(math.Sin((phi2-phi1)/2), 2) +
+		(math.Cos(phi1) * math.Cos(phi2) * math.Pow(math.Sin(dLambda/2), 2))
+	distance := 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	// Difference of the latitudes on the Mercator projection
+	dPsi := math.Log(math.Tan((math.Pi/4)+(phi2/2)) / math.Tan((math.Pi/4)+(phi1/2)))
+	rhumbBearing := utils.Degrees(math.Atan2(dLambda, dPsi))
+
+	return &QiblaDirection{
+		Bearing:      utils.UnwindAngle(bearing),
+		RhumbBearing: utils.UnwindAngle(rhumbBearing),
+		Distance:     distance,
+	}
+}
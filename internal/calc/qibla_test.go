@@ -0,0 +1,33 @@
+package calc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+func TestQibla(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		bearing   float64
+	}{
+		{"Jakarta", -6.2088, 106.8456, 295.15},
+		{"New York", 40.7128, -74.0060, 58.48},
+		{"London", 51.5074, -0.1278, 118.99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coords, err := utils.NewCoordinates(tt.latitude, tt.longitude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Qibla(coords).Bearing; math.Abs(got-tt.bearing) > 0.05 {
+				t.Errorf("bearing = %v, want %v", got, tt.bearing)
+			}
+		})
+	}
+}
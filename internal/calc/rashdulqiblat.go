@@ -0,0 +1,213 @@
+package calc
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Rashdul Qiblat
+
+Times when the shadow of a vertical object lines up with the qibla.
+
+Twice a year, around 28 May and 16 July, the sun passes over the Kaaba.
+At that instant the shadows on the half of the earth where the sun is up
+point away from the qibla.
+
+On every other day, the sun crosses the azimuth of the qibla or the
+opposite azimuth at a location. The shadow points away from the qibla
+or toward the qibla at that time.
+
+Reference: Astronomical Algorithm Chapter 15 Page 102 (transit at the Kaaba)
+Reference: Astronomical Algorithm Chapter 25 Page 165 (declination of the sun)
+*/
+type QiblaShadow struct {
+	Time time.Time
+
+	// The sun is above the Kaaba
+	Global bool
+
+	// The shadow points toward the qibla when the sun is opposite the qibla,
+	// otherwise it points away from the qibla
+	TowardQibla bool
+
+	// The sun is above the horizon of the location
+	Visible bool
+}
+
+// Interval to search the time when the sun crosses an azimuth
+const qiblaShadowStep = 5 * time.Minute
+
+// Instants of the given year when the sun is over the Kaaba.
+// It is the transit of the sun at the Kaaba on the date when
+// the declination of the sun is the closest to the latitude of the Kaaba
+func GlobalRashdulQiblat(year int, params *CalculationParameters) ([]time.Time, error) {
+	if params == nil {
+		params = NewCalculationParameter()
+	}
+	provider := params.SolarPosition
+	if provider == nil {
+		provider = MeeusSolarPosition{}
+	}
+
+	// Difference between the declination at the transit and the latitude of the Kaaba
+	distance := func(date time.Time) (time.Time, float64, error) {
+		dateComponents := utils.NewDateComponents(date)
+		solarTime := NewSolarTime(dateComponents, &Kaaba, params)
+		jd := GetJulianDay(dateComponents, solarTime.Transit)
+		solar := provider.SolarCoordinates(JulianEphemerisDay(jd))
+		transit, err := createDateComponents(solarTime.Transit, dateComponents)
+		return transit, solar.Declination - Kaaba.Latitude, err
+	}
+
+	var events []time.Time
+	start := time.Date(year, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.September, 1, 0, 0, 0, 0, time.UTC)
+	prevTransit, prevDistance, err := distance(start)
+	if err != nil {
+		return nil, err
+	}
+	for date := start.AddDate(0, 0, 1); date.Before(end); date = date.AddDate(0, 0, 1) {
+		transit, d, err := distance(date)
+		if err != nil {
+			return nil, err
+		}
+		if (prevDistance < 0) != (d < 0) {
+			if math.Abs(prevDistance) < math.Abs(d) {
+				events = append(events, prevTransit)
+			} else {
+				events = append(events, transit)
+			}
+		}
+		prevTransit, prevDistance = transit, d
+	}
+	return events, nil
+}
+
+// Find the time in [start, end] when the azimuth of the sun is 'azimuth'
+// by bisection. It is zero when the sun doesn't cross the azimuth
+func sunAzimuthTime(
+	coords *utils.Coordinates,
+	params *CalculationParameters,
+	azimuth float64,
+	start time.Time,
+	end time.Time,
+) time.Time {
+	difference := func(t time.Time) float64 {
+		return utils.ClosestAngle(SunPosition(coords, t, params).Azimuth - azimuth)
+	}
+	d1 := difference(start)
+	d2 := difference(end)
+	if (d1 < 0) == (d2 < 0) {
+		return time.Time{}
+	}
+	for end.Sub(start) > time.Second {
+		middle := start.Add(end.Sub(start) / 2)
+		d := difference(middle)
+		if (d < 0) == (d1 < 0) {
+			start, d1 = middle, d
+		} else {
+			end = middle
+		}
+	}
+	// The difference also changes its sign when the sun passes
+	// the opposite azimuth, it is about 180 degree there. The azimuth
+	// moves fast near the zenith, so the step isn't rejected before
+	if math.Abs(d1) > 90 {
+		return time.Time{}
+	}
+	return start.Round(time.Second)
+}
+
+// Times on the date between sunrise and sunset when the shadow
+// of a vertical object at 'coords' lines up with the qibla.
+// It is empty when the sun doesn't rise or doesn't cross the azimuths
+func DailyRashdulQiblat(coords *utils.Coordinates, date *utils.DateComponents, params *CalculationParameters) ([]QiblaShadow, error) {
+	if params == nil {
+		params = NewCalculationParameter()
+	}
+	qibla := Qibla(coords)
+	if qibla.Distance == 0 {
+		return nil, nil
+	}
+
+	solarTime := NewSolarTime(date, coords, params)
+	if solarTime.Err != nil {
+		return nil, nil
+	}
+	sunrise, err := createDateComponents(solarTime.Sunrise, date)
+	if err != nil {
+		return nil, err
+	}
+	sunset, err := createDateComponents(solarTime.Sunset, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var shadows []QiblaShadow
+	for t := sunrise; t.Before(sunset); t = t.Add(qiblaShadowStep) {
+		next := t.Add(qiblaShadowStep)
+		if next.After(sunset) {
+			next = sunset
+		}
+		for _, towardQibla := range []bool{false, true} {
+			azimuth := qibla.Bearing
+			if towardQibla {
+				azimuth = utils.UnwindAngle(azimuth + 180)
+			}
+			crossing := sunAzimuthTime(coords, params, azimuth, t, next)
+			if crossing.IsZero() {
+				continue
+			}
+			shadows = append(shadows, QiblaShadow{Time: crossing, TowardQibla: towardQibla, Visible: true})
+		}
+	}
+	sort.Slice(shadows, func(i, j int) bool {
+		return shadows[i].Time.Before(shadows[j].Time)
+	})
+	return shadows, nil
+}
+
+// Events of rashdul qiblat at 'coords' after 't' until 'days' days later.
+// The events are the global rashdul qiblat and the daily times of the location
+func RashdulQiblatEvents(coords *utils.Coordinates, t time.Time, days int, params *CalculationParameters) ([]QiblaShadow, error) {
+	end := t.AddDate(0, 0, days)
+
+	var events []QiblaShadow
+	for year := t.UTC().Year(); year <= end.UTC().Year(); year++ {
+		globals, err := GlobalRashdulQiblat(year, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, global := range globals {
+			if global.Before(t) || !global.Before(end) {
+				continue
+			}
+			events = append(events, QiblaShadow{
+				Time:    global,
+				Global:  true,
+				Visible: SunPosition(coords, global, params).Altitude > 0,
+			})
+		}
+	}
+
+	for date := t.UTC().AddDate(0, 0, -1); date.Before(end); date = date.AddDate(0, 0, 1) {
+		shadows, err := DailyRashdulQiblat(coords, utils.NewDateComponents(date), params)
+		if err != nil {
+			return nil, err
+		}
+		for _, shadow := range shadows {
+			if shadow.Time.Before(t) || !shadow.Time.Before(end) {
+				continue
+			}
+			events = append(events, shadow)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	return events, nil
+}
@@ -0,0 +1,77 @@
+package calc
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+// Published by Kemenag at 16:18 and 16:27 Western Indonesia Time (UTC+7),
+// the expected times are written in UTC
+func TestGlobalRashdulQiblat(t *testing.T) {
+	tests := []struct {
+		year  int
+		times []time.Time
+	}{
+		{2024, []time.Time{
+			time.Date(2024, time.May, 27, 9, 18, 0, 0, time.UTC),
+			time.Date(2024, time.July, 15, 9, 27, 0, 0, time.UTC),
+		}},
+		{2025, []time.Time{
+			time.Date(2025, time.May, 27, 9, 18, 0, 0, time.UTC),
+			time.Date(2025, time.July, 15, 9, 27, 0, 0, time.UTC),
+		}},
+	}
+
+	for _, tt := range tests {
+		times, err := GlobalRashdulQiblat(tt.year, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(times) != len(tt.times) {
+			t.Fatalf("GlobalRashdulQiblat(%v) = %v, want %v", tt.year, times, tt.times)
+		}
+		for i := range times {
+			if diff := times[i].Sub(tt.times[i]); diff < -time.Minute || diff > time.Minute {
+				t.Errorf("GlobalRashdulQiblat(%v)[%v] = %v, want %v", tt.year, i, times[i], tt.times[i])
+			}
+		}
+	}
+}
+
+// The sun passes near the zenith, its azimuth moves more than 90 degree
+// in a step of the search around the crossing
+func TestDailyRashdulQiblatNearZenith(t *testing.T) {
+	coords, err := utils.NewCoordinates(-10, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := utils.NewDateComponents(time.Date(2024, time.February, 23, 0, 0, 0, 0, time.UTC))
+	want := time.Date(2024, time.February, 23, 10, 53, 13, 0, time.UTC)
+
+	shadows, err := DailyRashdulQiblat(coords, date, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, shadow := range shadows {
+		found = found || (!shadow.TowardQibla && shadow.Time.Sub(want).Abs() <= time.Minute)
+	}
+	if !found {
+		t.Fatalf("DailyRashdulQiblat = %v, want a shadow away from the qibla at %v", shadows, want)
+	}
+
+	qibla := Qibla(coords).Bearing
+	for _, shadow := range shadows {
+		azimuth := qibla
+		if shadow.TowardQibla {
+			azimuth = utils.UnwindAngle(qibla + 180)
+		}
+		got := SunPosition(coords, shadow.Time, nil).Azimuth
+		if math.Abs(utils.ClosestAngle(got-azimuth)) > 5 {
+			t.Errorf("azimuth of the sun at %v = %v, want %v", shadow.Time, got, azimuth)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package calc
+
+import (
+	"math"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Atmospheric Refraction
+
+The atmosphere bends the light of the sun so the sun is seen higher
+than its true position. The effect is largest near the horizon and
+depends on the pressure and the temperature of the air.
+
+Reference: Astronomical Algorithm Chapter 16 Page 105
+*/
+type RefractionModel int8
+
+const (
+	// Fixed refraction of 34 arcminutes at the horizon.
+	// Other altitudes are not corrected
+	STANDARD_REFRACTION RefractionModel = iota
+
+	// Bennett formula, calculated from the apparent altitude.
+	// The accuracy is 0.07 arcminute
+	BENNETT
+
+	// Sæmundsson formula, calculated from the true altitude.
+	// Consistent with Bennett formula within 0.1 arcminute
+	SAEMUNDSSON
+)
+
+// Semidiameter of the sun in degrees
+const solarSemidiameter = 16.0 / 60.0
+
+// Standard atmosphere that used by refraction formulas
+const (
+	STANDARD_PRESSURE    = 1010.0
+	STANDARD_TEMPERATURE = 10.0
+)
+
+func atmosphericCorrection(pressure float64, temperature float64) float64 {
+	return (pressure / STANDARD_PRESSURE) * (283 / (273 + temperature))
+}
+
+// Bennett Refraction
+// returns the refraction in degrees
+//
+// Given 'apparentAltitude' in degrees, 'pressure' in millibars
+// and 'temperature' in celsius
+//
+// Reference: Chapter 16 Page 106
+func BennettRefraction(apparentAltitude float64, pressure float64, temperature float64) float64 {
+	R := 1 / math.Tan(utils.Radians(apparentAltitude+(7.31/(apparentAltitude+4.4))))
+	return (R / 60) * atmosphericCorrection(pressure, temperature)
+}
+
+// Saemundsson Refraction
+// returns the refraction in degrees
+//
+// Given 'trueAltitude' in degrees, 'pressure' in millibars
+// and 'temperature' in celsius
+//
+// Reference: Chapter 16 Page 106
+func SaemundssonRefraction(trueAltitude float64, pressure float64, temperature float64) float64 {
+	R := 1.02 / math.Tan(utils.Radians(trueAltitude+(10.3/(trueAltitude+5.11))))
+	return (R / 60) * atmosphericCorrection(pressure, temperature)
+}
+
+// Refraction of the model at the given apparent altitude in degrees.
+// It is zero for STANDARD_REFRACTION
+func (param *CalculationParameters) refraction(apparentAltitude float64) float64 {
+	switch param.Refraction {
+	case BENNETT:
+		return BennettRefraction(apparentAltitude, param.Pressure, param.Temperature)
+	case SAEMUNDSSON:
+		trueAltitude := apparentAltitude
+		for i := 0; i < 3; i++ {
+			trueAltitude = apparentAltitude - SaemundssonRefraction(trueAltitude, param.Pressure, param.Temperature)
+		}
+		return apparentAltitude - trueAltitude
+	default:
+		return 0
+	}
+}
+
+// True Altitude
+// returns the geometric altitude of the sun's center
+// when it is seen at the given apparent altitude
+//
+// Only altitudes above the horizon are corrected. Twilight angles
+// below the horizon are geometric depression angles and are returned
+// as is, sunrise and sunset are corrected by HorizonAltitude
+func (param *CalculationParameters) TrueAltitude(apparentAltitude float64) float64 {
+	if apparentAltitude < 0 {
+		return apparentAltitude
+	}
+	return apparentAltitude - param.refraction(apparentAltitude)
+}
+
+// Horizon Altitude
+// returns the altitude of the sun's center at sunrise and sunset,
+// when the upper limb of the sun touches the visible horizon
+func (param *CalculationParameters) HorizonAltitude(elevation float64) float64 {
+	dip := HorizonDip(elevation)
+	if param.Refraction == STANDARD_REFRACTION {
+		return (-50.0 / 60.0) - dip
+	}
+	return -dip - param.refraction(-dip) - solarSemidiameter
+}
@@ -0,0 +1,63 @@
+package calc
+
+import (
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+// Rounding of a prayer time
+type Rounding int8
+
+const (
+	// Round to the nearest minute. This is the default value
+	ROUND_NEAREST Rounding = iota
+
+	// Round up to the next minute when there are seconds,
+	// used for ihtiyat (precaution) so the prayer is never early
+	ROUND_UP
+
+	// Round down to the minute, used for sunrise and imsak
+	// so they are never late
+	ROUND_DOWN
+
+	// Keep the calculated time without rounding
+	ROUND_NONE
+
+	// Round to the nearest second
+	ROUND_SECOND
+)
+
+// Rounding of each prayer time
+type RoundingPolicy struct {
+	Imsak   Rounding
+	Fajr    Rounding
+	Sunrise Rounding
+	Dhuhr   Rounding
+	Asr     Rounding
+	Magrib  Rounding
+	Isha    Rounding
+}
+
+// Round the time. Zero time is kept because the prayer doesn't exist
+func (rounding Rounding) Round(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	switch rounding {
+	case ROUND_UP:
+		truncated := t.Truncate(time.Minute)
+		if truncated.Equal(t) {
+			return t
+		}
+		return truncated.Add(time.Minute)
+	case ROUND_DOWN:
+		return t.Truncate(time.Minute)
+	case ROUND_NONE:
+		return t
+	case ROUND_SECOND:
+		return t.Round(time.Second)
+	default:
+		return utils.RoundToNearestMinutes(t)
+	}
+}
@@ -14,6 +14,7 @@ type seasonAdjust struct {
 	d       float64
 	dyy     int
 	sunTime time.Time
+	evening bool
 }
 
 func DaysSinceSolstice(dayOfYear int, year int, latitude float64) int {
@@ -56,13 +57,21 @@ func (seasonAdj *seasonAdjust) adjust() time.Time {
 	default:
 		adjustment = seasonAdj.b + (seasonAdj.a-seasonAdj.b)/91*(float64(seasonAdj.dyy)-275)
 	}
+	if seasonAdj.evening {
+		return seasonAdj.sunTime.Add(time.Second * time.Duration(math.Round(adjustment*60)))
+	}
 	return seasonAdj.sunTime.Add(time.Second * time.Duration(-1*math.Round(adjustment*60)))
 }
 
+// Season Adjusted Morning Twilight
+// returns the Fajr time of the Moonsighting Committee method
+// that is calculated from the minutes before sunrise
+//
+// Reference: https://www.moonsighting.com/isha_fajr.html
 func SeasonAdjustedMorningTwilight(latitude float64, day int, year int, sunrise time.Time) time.Time {
 	a := 75 + ((28.65 / 55) * math.Abs(latitude))
-	b := 75 + ((10.44 / 55) * math.Abs(latitude))
-	c := 75 + ((19.44 / 55) * math.Abs(latitude))
+	b := 75 + ((19.44 / 55) * math.Abs(latitude))
+	c := 75 + ((32.74 / 55) * math.Abs(latitude))
 	d := 75 + ((48.10 / 55) * math.Abs(latitude))
 	dyy := DaysSinceSolstice(day, year, latitude)
 
@@ -77,11 +86,31 @@ func SeasonAdjustedMorningTwilight(latitude float64, day int, year int, sunrise
 	return res.adjust()
 }
 
-func SeasonAdjustedEveningTwilight(latitude float64, day int, year int, sunset time.Time) time.Time {
-	a := 75 + ((25.60 / 55) * math.Abs(latitude))
-	b := 75 + ((2.05 / 55) * math.Abs(latitude))
-	c := 75 + ((9.21 / 55) * math.Abs(latitude))
-	d := 75 + ((6.14 / 55) * math.Abs(latitude))
+// Season Adjusted Evening Twilight
+// returns the Isha time of the Moonsighting Committee method
+// that is calculated from the minutes after sunset
+// based on the given shafaq
+//
+// Reference: https://www.moonsighting.com/isha_fajr.html
+func SeasonAdjustedEveningTwilight(latitude float64, day int, year int, sunset time.Time, shafaq Shafaq) time.Time {
+	var a, b, c, d float64
+	switch shafaq {
+	case AHMAR:
+		a = 62 + ((17.40 / 55) * math.Abs(latitude))
+		b = 62 - ((7.16 / 55) * math.Abs(latitude))
+		c = 62 + ((5.12 / 55) * math.Abs(latitude))
+		d = 62 + ((19.44 / 55) * math.Abs(latitude))
+	case ABYAD:
+		a = 75 + ((25.60 / 55) * math.Abs(latitude))
+		b = 75 + ((7.16 / 55) * math.Abs(latitude))
+		c = 75 + ((36.84 / 55) * math.Abs(latitude))
+		d = 75 + ((81.84 / 55) * math.Abs(latitude))
+	default:
+		a = 75 + ((25.60 / 55) * math.Abs(latitude))
+		b = 75 + ((2.05 / 55) * math.Abs(latitude))
+		c = 75 - ((9.21 / 55) * math.Abs(latitude))
+		d = 75 + ((6.14 / 55) * math.Abs(latitude))
+	}
 	dyy := DaysSinceSolstice(day, year, latitude)
 
 	res := &seasonAdjust{
@@ -91,6 +120,7 @@ func SeasonAdjustedEveningTwilight(latitude float64, day int, year int, sunset t
 		d:       d,
 		dyy:     dyy,
 		sunTime: sunset,
+		evening: true,
 	}
 	return res.adjust()
 }
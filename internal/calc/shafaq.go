@@ -0,0 +1,25 @@
+package calc
+
+/*
+Shafaq
+
+The twilight used by the Moonsighting Committee to determine Isha.
+Shafaq ahmar is the red glow after sunset and shafaq abyad is the
+white glow that remains after the red one disappears.
+
+Reference: https://www.moonsighting.com/isha_fajr.html
+*/
+type Shafaq int8
+
+const (
+	// Combination of shafaq ahmar and shafaq abyad.
+	// This is the default value that used by Moonsighting Committee.
+	GENERAL Shafaq = iota
+
+	// Red twilight. Isha is earlier than other shafaq
+	AHMAR
+
+	// White twilight. Isha is later than other shafaq
+	// and may be unreachable in high latitude
+	ABYAD
+)
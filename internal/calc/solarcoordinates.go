@@ -38,3 +38,19 @@ func NewSolarCoordinates(jde float64) *SolarCoordinates {
 		ApparentSiderealTime: apparentSiderealTime,
 	}
 }
+
+// Apparent Sidereal Time at Greenwich in degrees
+//
+// Given 'jd', the julian day in universal time
+//
+// Reference: Astronomical Algorithm Chapter 12 Page 88
+func ApparentSiderealTime(jd float64) float64 {
+	T := GetJulianCentury(jd)
+	L0 := MeanSolarLongitude(T)
+	Lp := MeanLunarLongitude(T)
+	omega := AscendingLunarNodeLongitude(T)
+	dPsi := NutationInLongitude(L0, Lp, omega)
+	dEpsilon := NutationInObliquity(L0, Lp, omega)
+	epsilon0 := MeanObliquityOfTheEcliptic(T)
+	return MeanSiderealTime(T) + (dPsi * math.Cos(utils.Radians(epsilon0+dEpsilon)))
+}
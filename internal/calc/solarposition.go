@@ -0,0 +1,20 @@
+package calc
+
+// Provider of the apparent position of the sun.
+// It is used by SolarTime to calculate the transit,
+// sunrise, sunset and the time of a given sun altitude.
+type SolarPositionProvider interface {
+	// Returns the apparent position of the sun
+	// at the given julian ephemeris day
+	SolarCoordinates(jde float64) *SolarCoordinates
+}
+
+// Low accuracy position of the sun (0.01 degree).
+// This is the default provider.
+//
+// Reference: Astronomical Algorithm Chapter 25 Page 163
+type MeeusSolarPosition struct{}
+
+func (MeeusSolarPosition) SolarCoordinates(jde float64) *SolarCoordinates {
+	return NewSolarCoordinates(jde)
+}
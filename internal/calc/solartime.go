@@ -11,66 +11,106 @@ type SolarTime struct {
 	Sunrise float64
 	Sunset  float64
 
+	// ErrSunNeverRises or ErrSunNeverSets when the sun doesn't
+	// cross the horizon on the date. Sunrise and Sunset are NaN
+	Err error
+
 	Obsever            *utils.Coordinates
+	Params             *CalculationParameters
 	Solar              *SolarCoordinates
 	PrevSolar          *SolarCoordinates
 	NextSolar          *SolarCoordinates
 	ApproximateTransit float64
+
+	// Apparent sidereal time at 0h UT of the date
+	ApparentSiderealTime float64
+
+	// TT - UT of the date in seconds
+	DeltaT float64
 }
 
-func NewSolarTime(date *utils.DateComponents, coordinate *utils.Coordinates) *SolarTime {
-	jde := GetJulianDay(date, 0)
+func NewSolarTime(date *utils.DateComponents, coordinate *utils.Coordinates, params *CalculationParameters) *SolarTime {
+	if params == nil {
+		params = NewCalculationParameter()
+	}
+	provider := params.SolarPosition
+	if provider == nil {
+		provider = MeeusSolarPosition{}
+	}
+	jd := GetJulianDay(date, 0)
+	deltaT := DeltaT(JulianDayToYear(jd))
+	siderealTime := ApparentSiderealTime(jd)
 
-	solar := NewSolarCoordinates(jde)
-	prevSolar := NewSolarCoordinates(jde - 1)
-	nextSolar := NewSolarCoordinates(jde + 1)
+	// The sun position is taken at 0h TD of the date, Delta T is added
+	// when the position is interpolated in CorrectedTransit and CorrectedHourAngle
+	//
+	// Reference: Astronomical Algorithm Chapter 15 Page 102
+	solar := provider.SolarCoordinates(jd)
+	prevSolar := provider.SolarCoordinates(jd - 1)
+	nextSolar := provider.SolarCoordinates(jd + 1)
 
 	approximateTransit := ApproximateTransit(
-		coordinate.Longitude, solar.ApparentSiderealTime, solar.RightAscension,
+		coordinate.Longitude, siderealTime, solar.RightAscension,
 	)
-	solarAltitude := -50.0 / 60.0
+	solarAltitude := params.HorizonAltitude(coordinate.Elevation)
 	transit := CorrectedTransit(
-		approximateTransit, coordinate.Longitude, solar.ApparentSiderealTime,
+		approximateTransit, coordinate.Longitude, siderealTime,
 		solar.RightAscension, prevSolar.RightAscension, nextSolar.RightAscension,
+		deltaT,
 	)
-	sunrise := CorrectedHourAngle(
+	sunrise, err := CorrectedHourAngle(
 		approximateTransit, solarAltitude, coordinate, false,
-		solar.ApparentSiderealTime, solar.RightAscension, prevSolar.RightAscension,
+		siderealTime, solar.RightAscension, prevSolar.RightAscension,
 		nextSolar.RightAscension, solar.Declination, prevSolar.Declination,
-		nextSolar.Declination,
+		nextSolar.Declination, deltaT,
 	)
-	sunset := CorrectedHourAngle(
+	sunset, _ := CorrectedHourAngle(
 		approximateTransit, solarAltitude, coordinate, true,
-		solar.ApparentSiderealTime, solar.RightAscension, prevSolar.RightAscension,
+		siderealTime, solar.RightAscension, prevSolar.RightAscension,
 		nextSolar.RightAscension, solar.Declination, prevSolar.Declination,
-		nextSolar.Declination,
+		nextSolar.Declination, deltaT,
 	)
 
 	return &SolarTime{
 		Transit:            transit,
 		Sunrise:            sunrise,
 		Sunset:             sunset,
+		Err:                err,
 		Obsever:            coordinate,
+		Params:             params,
 		Solar:              solar,
 		PrevSolar:          prevSolar,
 		NextSolar:          nextSolar,
 		ApproximateTransit: approximateTransit,
+
+		ApparentSiderealTime: siderealTime,
+		DeltaT:               deltaT,
 	}
 }
 
-func (solar *SolarTime) HourAngle(angle float64, afterTransit bool) float64 {
+func (solar *SolarTime) HourAngle(angle float64, afterTransit bool) (float64, error) {
 	return CorrectedHourAngle(
-		solar.ApproximateTransit, angle, solar.Obsever, afterTransit,
-		solar.Solar.ApparentSiderealTime, solar.Solar.RightAscension,
+		solar.ApproximateTransit, solar.Params.TrueAltitude(angle), solar.Obsever, afterTransit,
+		solar.ApparentSiderealTime, solar.Solar.RightAscension,
 		solar.PrevSolar.RightAscension, solar.NextSolar.RightAscension,
 		solar.Solar.Declination, solar.PrevSolar.Declination,
-		solar.NextSolar.Declination,
+		solar.NextSolar.Declination, solar.DeltaT,
 	)
 }
 
-func (solar *SolarTime) Afternoon(sl utils.ShadowLength) float64 {
+func (solar *SolarTime) Afternoon(sl utils.ShadowLength) (float64, error) {
+	return solar.AfternoonByShadowFactor(utils.ShadowLengthToFloatMap[sl])
+}
+
+// Time when the shadow of an object is 'factor' times its length
+// added by the length of its shadow at noon
+func (solar *SolarTime) AfternoonByShadowFactor(factor float64) (float64, error) {
 	tangent := math.Abs(solar.Obsever.Latitude - solar.Solar.Declination)
-	inverse := utils.ShadowLengthToFloatMap[sl] + math.Tan(utils.Radians(tangent))
+	// The sun is below the horizon at noon so there is no shadow
+	if tangent >= 90 {
+		return math.NaN(), ErrSunNeverRises
+	}
+	inverse := factor + math.Tan(utils.Radians(tangent))
 	angle := utils.Degrees(math.Atan(1.0 / inverse))
 
 	return solar.HourAngle(angle, true)
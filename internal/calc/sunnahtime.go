@@ -0,0 +1,106 @@
+package calc
+
+import (
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Sunnah Times
+
+Windows of the voluntary prayers in the morning and the windows
+where praying is forbidden (makruh). The windows are calculated
+from the altitude of the sun in SolarTime.
+
+The forbidden windows are:
+  - after sunrise until the sun has risen a spear's length
+  - istiwa, when the sun is at its zenith until it passes the meridian
+  - after the sun becomes yellow until sunset
+*/
+type SunnahTimes struct {
+	// From the end of the forbidden window at sunrise until Dhuha
+	Ishraq Interval
+
+	// From the Dhuha angle until the forbidden window at zawal
+	Dhuha Interval
+
+	ForbiddenAtSunrise Interval
+	ForbiddenAtZawal   Interval
+	ForbiddenAtSunset  Interval
+}
+
+// Window between two times. The window doesn't exist
+// on the date when its start or its end is zero
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (interval Interval) IsZero() bool {
+	return interval.Start.IsZero() || interval.End.IsZero()
+}
+
+// Returns true when 't' is in [Start, End)
+func (interval Interval) Contains(t time.Time) bool {
+	if interval.IsZero() {
+		return false
+	}
+	return !t.Before(interval.Start) && t.Before(interval.End)
+}
+
+// Time when the sun is at the given altitude above the horizon.
+// It is zero when the sun doesn't reach the altitude on the date
+func sunAltitudeTime(
+	solarTime *SolarTime,
+	date *utils.DateComponents,
+	altitude float32,
+	afterTransit bool,
+	loc *time.Location,
+) (time.Time, error) {
+	hours, err := solarTime.HourAngle(float64(altitude), afterTransit)
+	if err != nil {
+		return time.Time{}, nil
+	}
+	t, err := createDateComponents(hours, date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return adjustTime(t, 0, ROUND_NEAREST).In(loc), nil
+}
+
+// Calculate the sunnah times on the date of the prayer times.
+// A window is zero when the sun doesn't reach its altitude on the date.
+func NewSunnahTimes(prayerTimes *PrayerTimes) (*SunnahTimes, error) {
+	params := prayerTimes.CalculationParams
+	date := prayerTimes.DateComponent
+	solarTime := NewSolarTime(date, prayerTimes.Coordinates, params)
+	loc := prayerTimes.Dhuhr.Location()
+
+	spearMorning, err := sunAltitudeTime(solarTime, date, params.SpearLengthAngle, false, loc)
+	if err != nil {
+		return nil, err
+	}
+	dhuhaStart, err := sunAltitudeTime(solarTime, date, params.DhuhaAngle, false, loc)
+	if err != nil {
+		return nil, err
+	}
+	spearEvening, err := sunAltitudeTime(solarTime, date, params.SpearLengthAngle, true, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	transit, err := createDateComponents(solarTime.Transit, date)
+	if err != nil {
+		return nil, err
+	}
+	zawalStart := adjustTime(transit, -params.ZawalInterval, ROUND_NEAREST).In(loc)
+
+	return &SunnahTimes{
+		Ishraq:             Interval{Start: spearMorning, End: dhuhaStart},
+		Dhuha:              Interval{Start: dhuhaStart, End: zawalStart},
+		ForbiddenAtSunrise: Interval{Start: prayerTimes.Sunrise, End: spearMorning},
+		ForbiddenAtZawal:   Interval{Start: zawalStart, End: prayerTimes.Dhuhr},
+		ForbiddenAtSunset:  Interval{Start: spearEvening, End: prayerTimes.Magrib},
+	}, nil
+}
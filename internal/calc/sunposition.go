@@ -0,0 +1,137 @@
+package calc
+
+import (
+	"math"
+	"time"
+
+	"github.com/taufiq30s/adzan/internal/utils"
+)
+
+/*
+Sun Position
+
+Position of the sun in the sky of an observer at an instant.
+The depression angle of the sun is used to check an observation
+of Fajr or Isha against the twilight angle of a method.
+
+Reference: Astronomical Algorithm Chapter 13 Page 93
+Reference: Astronomical Algorithm Chapter 28 Page 183
+*/
+type HorizontalCoordinates struct {
+	Time time.Time
+
+	// Geometric altitude of the sun's center in degrees,
+	// corrected for the parallax of the observer
+	Altitude float64
+
+	// Altitude of the sun's center that is seen through the atmosphere
+	ApparentAltitude float64
+
+	// Angle of the sun below the horizon (-Altitude).
+	// It is negative when the sun is above the horizon
+	Depression float64
+
+	// Azimuth in degrees, measured eastward from the north
+	Azimuth float64
+
+	// Local hour angle in degrees, negative before the transit
+	HourAngle float64
+
+	Declination    float64
+	RightAscension float64
+
+	// Apparent solar time minus mean solar time in minutes
+	EquationOfTime float64
+}
+
+// Equatorial horizontal parallax of the sun in degrees
+const solarParallax = 8.794 / 3600
+
+// Equation of Time
+// returns the equation of time in degrees
+//
+// Given 'jde', the julian ephemeris day and 'rightAscension',
+// the apparent right ascension of the sun
+//
+// Reference: Astronomical Algorithm Chapter 28 Page 183
+func EquationOfTime(jde float64, rightAscension float64) float64 {
+	T := GetJulianCentury(jde)
+	L0 := MeanSolarLongitude(T)
+	Lp := MeanLunarLongitude(T)
+	omega := AscendingLunarNodeLongitude(T)
+	dPsi := NutationInLongitude(L0, Lp, omega)
+	epsilon := MeanObliquityOfTheEcliptic(T) + NutationInObliquity(L0, Lp, omega)
+	return utils.ClosestAngle(utils.UnwindAngle(
+		L0 - 0.0057183 - rightAscension + (dPsi * math.Cos(utils.Radians(epsilon))),
+	))
+}
+
+// Apparent Altitude
+// returns the altitude of the sun that is seen through
+// the atmosphere when its geometric altitude is 'trueAltitude'
+// by the refraction model of the parameter
+//
+// STANDARD_REFRACTION only corrects sunrise and sunset, so the altitude
+// is returned as is. Altitudes more than 1 degree below the horizon,
+// where both formulas lose their accuracy, are also returned as is
+func (param *CalculationParameters) ApparentAltitude(trueAltitude float64) float64 {
+	if trueAltitude < -1 {
+		return trueAltitude
+	}
+	switch param.Refraction {
+	case BENNETT:
+		apparentAltitude := trueAltitude
+		for i := 0; i < 3; i++ {
+			apparentAltitude = trueAltitude + BennettRefraction(apparentAltitude, param.Pressure, param.Temperature)
+		}
+		return apparentAltitude
+	case SAEMUNDSSON:
+		return trueAltitude + SaemundssonRefraction(trueAltitude, param.Pressure, param.Temperature)
+	default:
+		return trueAltitude
+	}
+}
+
+// Position of the sun at the instant 't' that is seen from 'coords'.
+// The refraction and the provider of the sun position are taken from 'params'
+func SunPosition(coords *utils.Coordinates, t time.Time, params *CalculationParameters) *HorizontalCoordinates {
+	if params == nil {
+		params = NewCalculationParameter()
+	}
+	provider := params.SolarPosition
+	if provider == nil {
+		provider = MeeusSolarPosition{}
+	}
+
+	utc := t.UTC()
+	hours := float64(utc.Hour()) + (float64(utc.Minute()) / 60) +
+		((float64(utc.Second()) + (float64(utc.Nanosecond()) / 1e9)) / 3600)
+	jd := GetJulianDay(utils.NewDateComponents(utc), hours)
+	jde := JulianEphemerisDay(jd)
+	solar := provider.SolarCoordinates(jde)
+
+	H := utils.ClosestAngle(utils.UnwindAngle(
+		ApparentSiderealTime(jd) + coords.Longitude - solar.RightAscension,
+	))
+	altitude := AltitudeOfCelestialBody(coords.Latitude, solar.Declination, H)
+	altitude -= solarParallax * math.Cos(utils.Radians(altitude))
+
+	// Meeus measures the azimuth westward from the south
+	phi := utils.Radians(coords.Latitude)
+	azimuth := utils.Degrees(math.Atan2(
+		math.Sin(utils.Radians(H)),
+		(math.Cos(utils.Radians(H))*math.Sin(phi))-(math.Tan(utils.Radians(solar.Declination))*math.Cos(phi)),
+	))
+
+	return &HorizontalCoordinates{
+		Time:             t,
+		Altitude:         altitude,
+		ApparentAltitude: params.ApparentAltitude(altitude),
+		Depression:       -altitude,
+		Azimuth:          utils.UnwindAngle(azimuth + 180),
+		HourAngle:        H,
+		Declination:      solar.Declination,
+		RightAscension:   solar.RightAscension,
+		EquationOfTime:   EquationOfTime(jde, solar.RightAscension) * 4,
+	}
+}
@@ -0,0 +1,34 @@
+package calc
+
+import (
+	"math"
+	"testing"
+)
+
+// Reference: Astronomical Algorithm Chapter 25 Page 165 (Example 25.a and 25.b)
+func TestSolarCoordinates(t *testing.T) {
+	tests := []struct {
+		name           string
+		provider       SolarPositionProvider
+		rightAscension float64
+		declination    float64
+		tolerance      float64
+	}{
+		{"Meeus", MeeusSolarPosition{}, 198.38083, -7.78507, 0.0001},
+		{"VSOP87", VSOP87SolarPosition{}, 198.378121, -7.783817, 0.0005},
+	}
+
+	// 1992 October 13 at 0h TD
+	jde := 2448908.5
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solar := tt.provider.SolarCoordinates(jde)
+			if math.Abs(solar.RightAscension-tt.rightAscension) > tt.tolerance {
+				t.Errorf("right ascension = %v, want %v", solar.RightAscension, tt.rightAscension)
+			}
+			if math.Abs(solar.Declination-tt.declination) > tt.tolerance {
+				t.Errorf("declination = %v, want %v", solar.Declination, tt.declination)
+			}
+		})
+	}
+}
@@ -9,8 +9,12 @@ import (
 func NewRoute() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/hijr", api.ShowCurrentHijrDate)
-	// mux.HandleFunc("/adzan", api.TodayAdzan)
-	// mux.HandleFunc("/adzan/month", api.MonthlyAdzan)
+	mux.HandleFunc("/adzan", api.TodayAdzan)
+	mux.HandleFunc("/adzan/month", api.MonthlyAdzan)
+	mux.HandleFunc("/methods", api.Methods)
+	mux.HandleFunc("/sun", api.SunPosition)
+	mux.HandleFunc("/qibla", api.Qibla)
+	mux.HandleFunc("/qibla/rashdul", api.RashdulQiblat)
 	return mux
 }
 
@@ -5,6 +5,9 @@ import "fmt"
 type Coordinates struct {
 	Latitude  float64
 	Longitude float64
+
+	// Height of observer above sea level in meters
+	Elevation float64
 }
 
 func NewCoordinates(latitude float64, longitude float64) (*Coordinates, error) {
@@ -19,3 +22,11 @@ func NewCoordinates(latitude float64, longitude float64) (*Coordinates, error) {
 		Longitude: longitude,
 	}, nil
 }
+
+func (coords *Coordinates) SetElevation(elevation float64) (*Coordinates, error) {
+	if elevation < -500 || elevation > 9000 {
+		return nil, fmt.Errorf("elevation must be between -500 and 9000 meters")
+	}
+	coords.Elevation = elevation
+	return coords, nil
+}